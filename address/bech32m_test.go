@@ -0,0 +1,46 @@
+package address
+
+import "testing"
+
+func TestDecodeBech32m_BIP350Vectors(t *testing.T) {
+	valid := []string{
+		"A1LQFN3A",
+		"a1lqfn3a",
+		"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		"?1v759aa",
+	}
+	for _, s := range valid {
+		if _, _, err := decodeBech32m(s); err != nil {
+			t.Fatalf("%q: %v", s, err)
+		}
+	}
+
+	invalid := []string{
+		"A1G7SGD8", // valid bech32, not bech32m
+		"a1lqfn3A", // mixed case
+		"1lqfn3a",  // empty hrp
+		"a1lqfn3",  // too short for a checksum
+		"a1lqfn3b", // bad checksum
+		"a1lqfn3i", // invalid character
+		"a\x801lqfn3a",
+	}
+	for _, s := range invalid {
+		if _, _, err := decodeBech32m(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
+
+func TestBech32m_RoundTrip(t *testing.T) {
+	data := []byte{0, 1, 2, 3, 31, 30, 29}
+	s := encodeBech32m("jtest", data)
+	hrp, got, err := decodeBech32m(s)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if hrp != "jtest" || string(got) != string(data) {
+		t.Fatalf("hrp=%q data=%v", hrp, got)
+	}
+}
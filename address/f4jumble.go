@@ -0,0 +1,73 @@
+package address
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Abdullah1738/juno-sdk-go/internal/blake2b"
+)
+
+const (
+	f4JumbleMinLen = 48
+	f4JumbleMaxLen = 4194368
+	f4JumbleHashH  = 64
+)
+
+// F4Jumble applies the ZIP-316 F4Jumble permutation to m.
+func F4Jumble(m []byte) ([]byte, error) {
+	if err := checkF4JumbleLen(len(m)); err != nil {
+		return nil, err
+	}
+	lenL := min(f4JumbleHashH, len(m)/2)
+	a := append([]byte(nil), m[:lenL]...)
+	b := append([]byte(nil), m[lenL:]...)
+
+	xorInto(b, f4G(0, a, len(b)))
+	xorInto(a, f4H(0, b, len(a)))
+	xorInto(b, f4G(1, a, len(b)))
+	xorInto(a, f4H(1, b, len(a)))
+	return append(a, b...), nil
+}
+
+// F4JumbleInv inverts F4Jumble.
+func F4JumbleInv(m []byte) ([]byte, error) {
+	if err := checkF4JumbleLen(len(m)); err != nil {
+		return nil, err
+	}
+	lenL := min(f4JumbleHashH, len(m)/2)
+	c := append([]byte(nil), m[:lenL]...)
+	d := append([]byte(nil), m[lenL:]...)
+
+	xorInto(c, f4H(1, d, len(c)))
+	xorInto(d, f4G(1, c, len(d)))
+	xorInto(c, f4H(0, d, len(c)))
+	xorInto(d, f4G(0, c, len(d)))
+	return append(c, d...), nil
+}
+
+func checkF4JumbleLen(n int) error {
+	if n < f4JumbleMinLen || n > f4JumbleMaxLen {
+		return fmt.Errorf("address: f4jumble input length %d out of range", n)
+	}
+	return nil
+}
+
+func f4H(i byte, u []byte, outLen int) []byte {
+	personal := append([]byte("UA_F4Jumble_H"), i, 0, 0)
+	return blake2b.Sum(outLen, personal, u)
+}
+
+func f4G(i byte, u []byte, outLen int) []byte {
+	out := make([]byte, 0, outLen+blake2b.Size)
+	for j := 0; len(out) < outLen; j++ {
+		personal := binary.LittleEndian.AppendUint16(append([]byte("UA_F4Jumble_G"), i), uint16(j))
+		out = append(out, blake2b.Sum(blake2b.Size, personal, u)...)
+	}
+	return out[:outLen]
+}
+
+func xorInto(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
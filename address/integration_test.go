@@ -0,0 +1,59 @@
+//go:build integration
+
+package address_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
+)
+
+func TestParse_RegtestNode_Integration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	node, err := junoregtest.Start(ctx, junoregtest.Config{})
+	if err != nil {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
+			t.Skip("junocashd not found in PATH")
+		}
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = node.Stop(context.Background()) }()
+
+	var acct struct {
+		Account uint32 `json:"account"`
+	}
+	if err := node.Client().Call(ctx, "z_getnewaccount", []any{}, &acct); err != nil {
+		t.Fatalf("z_getnewaccount: %v", err)
+	}
+	var resp struct {
+		Address string `json:"address"`
+	}
+	if err := node.Client().Call(ctx, "z_getaddressforaccount", []any{acct.Account, []string{"orchard"}}, &resp); err != nil {
+		t.Fatalf("z_getaddressforaccount: %v", err)
+	}
+	ua, err := address.ParseUnifiedAddress(resp.Address)
+	if err != nil {
+		t.Fatalf("ParseUnifiedAddress(%q): %v", resp.Address, err)
+	}
+	if _, ok := ua.Orchard(); ua.Network != address.Regtest || !ok {
+		t.Fatalf("address %q: network=%s orchard=%v", resp.Address, ua.Network, ok)
+	}
+
+	var ufvk string
+	if err := node.Client().Call(ctx, "z_exportviewingkey", []any{resp.Address}, &ufvk); err != nil {
+		t.Fatalf("z_exportviewingkey: %v", err)
+	}
+	key, err := address.ParseUnifiedFullViewingKey(ufvk)
+	if err != nil {
+		t.Fatalf("ParseUnifiedFullViewingKey(%q): %v", ufvk, err)
+	}
+	if _, ok := key.Orchard(); key.Network != address.Regtest || !ok {
+		t.Fatalf("ufvk %q: network=%s orchard=%v", ufvk, key.Network, ok)
+	}
+}
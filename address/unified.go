@@ -0,0 +1,448 @@
+// Package address decodes and validates Juno unified addresses and unified full
+// viewing keys (ZIP-316): Bech32m over an F4Jumble-d sequence of typed items.
+package address
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Network string
+
+const (
+	Mainnet Network = "main"
+	Testnet Network = "test"
+	Regtest Network = "regtest"
+)
+
+// Human-readable parts for each network. The integration tests decode the unified address
+// and UFVK of a regtest junocashd account with the regtest parts.
+const (
+	MainnetAddressHRP = "j"
+	TestnetAddressHRP = "jtest"
+	RegtestAddressHRP = "jregtest"
+
+	MainnetUFVKHRP = "jview"
+	TestnetUFVKHRP = "jviewtest"
+	RegtestUFVKHRP = "jviewregtest"
+)
+
+// ParseNetwork maps a chain name, as reported by getblockchaininfo or stored in
+// types.TxPlan.Chain, to a Network.
+func ParseNetwork(chain string) (Network, error) {
+	switch strings.ToLower(strings.TrimSpace(chain)) {
+	case "main", "mainnet":
+		return Mainnet, nil
+	case "test", "testnet":
+		return Testnet, nil
+	case "regtest":
+		return Regtest, nil
+	default:
+		return "", fmt.Errorf("address: unknown chain %q", chain)
+	}
+}
+
+func (n Network) AddressHRP() string {
+	switch n {
+	case Mainnet:
+		return MainnetAddressHRP
+	case Testnet:
+		return TestnetAddressHRP
+	case Regtest:
+		return RegtestAddressHRP
+	default:
+		return ""
+	}
+}
+
+func (n Network) UFVKHRP() string {
+	switch n {
+	case Mainnet:
+		return MainnetUFVKHRP
+	case Testnet:
+		return TestnetUFVKHRP
+	case Regtest:
+		return RegtestUFVKHRP
+	default:
+		return ""
+	}
+}
+
+type Typecode uint32
+
+const (
+	TypecodeP2PKH   Typecode = 0x00
+	TypecodeP2SH    Typecode = 0x01
+	TypecodeSapling Typecode = 0x02
+	TypecodeOrchard Typecode = 0x03
+
+	// Typecodes in this range are "must-understand" metadata; none are defined yet,
+	// so encodings containing them are rejected.
+	mustUnderstandMin Typecode = 0xE0
+	mustUnderstandMax Typecode = 0xFC
+)
+
+const (
+	OrchardReceiverSize = 43
+	SaplingReceiverSize = 43
+	P2PKHReceiverSize   = 20
+	P2SHReceiverSize    = 20
+
+	OrchardFVKSize = 96
+	SaplingFVKSize = 128
+	P2PKHFVKSize   = 65
+
+	paddingSize = 16
+)
+
+var (
+	ErrInvalidEncoding = errors.New("address: invalid unified encoding")
+	ErrWrongNetwork    = errors.New("address: wrong network")
+)
+
+type Item struct {
+	Typecode Typecode
+	Data     []byte
+}
+
+type UnifiedAddress struct {
+	Network Network
+	Items   []Item
+}
+
+type UnifiedFullViewingKey struct {
+	Network Network
+	Items   []Item
+}
+
+// ParseUnifiedAddress decodes and validates a unified address.
+func ParseUnifiedAddress(s string) (*UnifiedAddress, error) {
+	net, items, err := decodeUnified(strings.TrimSpace(s), Network.AddressHRP)
+	if err != nil {
+		return nil, err
+	}
+	ua := &UnifiedAddress{Network: net, Items: items}
+	if err := ua.validate(); err != nil {
+		return nil, err
+	}
+	return ua, nil
+}
+
+// ParseUnifiedFullViewingKey decodes and validates a unified full viewing key.
+func ParseUnifiedFullViewingKey(s string) (*UnifiedFullViewingKey, error) {
+	net, items, err := decodeUnified(strings.TrimSpace(s), Network.UFVKHRP)
+	if err != nil {
+		return nil, err
+	}
+	ufvk := &UnifiedFullViewingKey{Network: net, Items: items}
+	if err := ufvk.validate(); err != nil {
+		return nil, err
+	}
+	return ufvk, nil
+}
+
+// ValidateAddress reports whether s is a well-formed unified address on any Juno network.
+func ValidateAddress(s string) error {
+	_, err := ParseUnifiedAddress(s)
+	return err
+}
+
+// ValidateAddressForNetwork is like ValidateAddress but also requires the given network.
+func ValidateAddressForNetwork(s string, net Network) error {
+	ua, err := ParseUnifiedAddress(s)
+	if err != nil {
+		return err
+	}
+	if ua.Network != net {
+		return fmt.Errorf("%w: address is for %s, want %s", ErrWrongNetwork, ua.Network, net)
+	}
+	return nil
+}
+
+// ValidateUFVK reports whether s is a well-formed unified full viewing key on any Juno network.
+func ValidateUFVK(s string) error {
+	_, err := ParseUnifiedFullViewingKey(s)
+	return err
+}
+
+// Encode validates ua and returns its string form. Items may be given in any order.
+func (ua *UnifiedAddress) Encode() (string, error) {
+	sorted := &UnifiedAddress{Network: ua.Network, Items: sortItems(ua.Items)}
+	if err := sorted.validate(); err != nil {
+		return "", err
+	}
+	return encodeUnified(sorted.Network.AddressHRP(), sorted.Items)
+}
+
+func (ua *UnifiedAddress) Orchard() ([OrchardReceiverSize]byte, bool) {
+	var out [OrchardReceiverSize]byte
+	return out, copyItem(ua.Items, TypecodeOrchard, out[:])
+}
+
+func (ua *UnifiedAddress) Sapling() ([SaplingReceiverSize]byte, bool) {
+	var out [SaplingReceiverSize]byte
+	return out, copyItem(ua.Items, TypecodeSapling, out[:])
+}
+
+func (ua *UnifiedAddress) P2PKH() ([P2PKHReceiverSize]byte, bool) {
+	var out [P2PKHReceiverSize]byte
+	return out, copyItem(ua.Items, TypecodeP2PKH, out[:])
+}
+
+func (ua *UnifiedAddress) P2SH() ([P2SHReceiverSize]byte, bool) {
+	var out [P2SHReceiverSize]byte
+	return out, copyItem(ua.Items, TypecodeP2SH, out[:])
+}
+
+// Encode validates k and returns its string form. Items may be given in any order.
+func (k *UnifiedFullViewingKey) Encode() (string, error) {
+	sorted := &UnifiedFullViewingKey{Network: k.Network, Items: sortItems(k.Items)}
+	if err := sorted.validate(); err != nil {
+		return "", err
+	}
+	return encodeUnified(sorted.Network.UFVKHRP(), sorted.Items)
+}
+
+func (k *UnifiedFullViewingKey) Orchard() ([OrchardFVKSize]byte, bool) {
+	var out [OrchardFVKSize]byte
+	return out, copyItem(k.Items, TypecodeOrchard, out[:])
+}
+
+func (k *UnifiedFullViewingKey) Sapling() ([SaplingFVKSize]byte, bool) {
+	var out [SaplingFVKSize]byte
+	return out, copyItem(k.Items, TypecodeSapling, out[:])
+}
+
+func (k *UnifiedFullViewingKey) P2PKH() ([P2PKHFVKSize]byte, bool) {
+	var out [P2PKHFVKSize]byte
+	return out, copyItem(k.Items, TypecodeP2PKH, out[:])
+}
+
+func (ua *UnifiedAddress) validate() error {
+	if ua.Network.AddressHRP() == "" {
+		return fmt.Errorf("address: unknown network %q", ua.Network)
+	}
+	sizes := map[Typecode]int{
+		TypecodeP2PKH:   P2PKHReceiverSize,
+		TypecodeP2SH:    P2SHReceiverSize,
+		TypecodeSapling: SaplingReceiverSize,
+		TypecodeOrchard: OrchardReceiverSize,
+	}
+	if err := validateItems(ua.Items, sizes); err != nil {
+		return err
+	}
+	if hasItem(ua.Items, TypecodeP2PKH) && hasItem(ua.Items, TypecodeP2SH) {
+		return fmt.Errorf("%w: both p2pkh and p2sh receivers", ErrInvalidEncoding)
+	}
+	return nil
+}
+
+func (k *UnifiedFullViewingKey) validate() error {
+	if k.Network.UFVKHRP() == "" {
+		return fmt.Errorf("address: unknown network %q", k.Network)
+	}
+	if hasItem(k.Items, TypecodeP2SH) {
+		return fmt.Errorf("%w: p2sh item in viewing key", ErrInvalidEncoding)
+	}
+	sizes := map[Typecode]int{
+		TypecodeP2PKH:   P2PKHFVKSize,
+		TypecodeSapling: SaplingFVKSize,
+		TypecodeOrchard: OrchardFVKSize,
+	}
+	return validateItems(k.Items, sizes)
+}
+
+func validateItems(items []Item, sizes map[Typecode]int) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: no items", ErrInvalidEncoding)
+	}
+	shielded := false
+	for i, it := range items {
+		if i > 0 && it.Typecode <= items[i-1].Typecode {
+			return fmt.Errorf("%w: items not in ascending typecode order", ErrInvalidEncoding)
+		}
+		if it.Typecode >= mustUnderstandMin && it.Typecode <= mustUnderstandMax {
+			return fmt.Errorf("%w: unsupported must-understand typecode 0x%x", ErrInvalidEncoding, uint32(it.Typecode))
+		}
+		if want, ok := sizes[it.Typecode]; ok && len(it.Data) != want {
+			return fmt.Errorf("%w: typecode 0x%x has length %d, want %d", ErrInvalidEncoding, uint32(it.Typecode), len(it.Data), want)
+		}
+		if it.Typecode != TypecodeP2PKH && it.Typecode != TypecodeP2SH {
+			shielded = true
+		}
+	}
+	if !shielded {
+		return fmt.Errorf("%w: no shielded item", ErrInvalidEncoding)
+	}
+	return nil
+}
+
+func decodeUnified(s string, hrpFor func(Network) string) (Network, []Item, error) {
+	hrp, data, err := decodeBech32m(s)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var net Network
+	for _, n := range []Network{Mainnet, Testnet, Regtest} {
+		if hrpFor(n) == hrp {
+			net = n
+		}
+	}
+	if net == "" {
+		return "", nil, fmt.Errorf("address: unexpected hrp %q", hrp)
+	}
+
+	jumbled, err := convertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	raw, err := F4JumbleInv(jumbled)
+	if err != nil {
+		return "", nil, err
+	}
+	if !bytes.Equal(raw[len(raw)-paddingSize:], hrpPadding(hrp)) {
+		return "", nil, fmt.Errorf("%w: bad padding", ErrInvalidEncoding)
+	}
+	raw = raw[:len(raw)-paddingSize]
+
+	var items []Item
+	r := &itemReader{buf: raw}
+	for r.remaining() > 0 {
+		typecode, err := r.compactSize()
+		if err != nil {
+			return "", nil, err
+		}
+		n, err := r.compactSize()
+		if err != nil {
+			return "", nil, err
+		}
+		if n > uint64(r.remaining()) {
+			return "", nil, fmt.Errorf("%w: truncated item", ErrInvalidEncoding)
+		}
+		if typecode > 0xffffffff {
+			return "", nil, fmt.Errorf("%w: typecode out of range", ErrInvalidEncoding)
+		}
+		items = append(items, Item{Typecode: Typecode(typecode), Data: r.bytes(int(n))})
+	}
+	return net, items, nil
+}
+
+func sortItems(items []Item) []Item {
+	sorted := append([]Item(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Typecode < sorted[j].Typecode })
+	return sorted
+}
+
+func encodeUnified(hrp string, items []Item) (string, error) {
+	var raw []byte
+	for _, it := range items {
+		raw = appendCompactSize(raw, uint64(it.Typecode))
+		raw = appendCompactSize(raw, uint64(len(it.Data)))
+		raw = append(raw, it.Data...)
+	}
+	raw = append(raw, hrpPadding(hrp)...)
+
+	jumbled, err := F4Jumble(raw)
+	if err != nil {
+		return "", err
+	}
+	data, err := convertBits(jumbled, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encodeBech32m(hrp, data), nil
+}
+
+func hrpPadding(hrp string) []byte {
+	p := make([]byte, paddingSize)
+	copy(p, hrp)
+	return p
+}
+
+func hasItem(items []Item, tc Typecode) bool {
+	for _, it := range items {
+		if it.Typecode == tc {
+			return true
+		}
+	}
+	return false
+}
+
+func copyItem(items []Item, tc Typecode, dst []byte) bool {
+	for _, it := range items {
+		if it.Typecode == tc && len(it.Data) == len(dst) {
+			copy(dst, it.Data)
+			return true
+		}
+	}
+	return false
+}
+
+type itemReader struct {
+	buf []byte
+	off int
+}
+
+func (r *itemReader) remaining() int {
+	return len(r.buf) - r.off
+}
+
+func (r *itemReader) bytes(n int) []byte {
+	b := append([]byte(nil), r.buf[r.off:r.off+n]...)
+	r.off += n
+	return b
+}
+
+func (r *itemReader) compactSize() (uint64, error) {
+	if r.remaining() < 1 {
+		return 0, fmt.Errorf("%w: truncated item header", ErrInvalidEncoding)
+	}
+	tag := r.buf[r.off]
+	r.off++
+
+	var n int
+	var min uint64
+	switch tag {
+	case 0xfd:
+		n, min = 2, 0xfd
+	case 0xfe:
+		n, min = 4, 0x10000
+	case 0xff:
+		n, min = 8, 0x100000000
+	default:
+		return uint64(tag), nil
+	}
+	if r.remaining() < n {
+		return 0, fmt.Errorf("%w: truncated item header", ErrInvalidEncoding)
+	}
+	var v uint64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(r.buf[r.off+i])
+	}
+	r.off += n
+	if v < min {
+		return 0, fmt.Errorf("%w: non-canonical compact size", ErrInvalidEncoding)
+	}
+	return v, nil
+}
+
+func appendCompactSize(b []byte, v uint64) []byte {
+	switch {
+	case v < 0xfd:
+		return append(b, byte(v))
+	case v <= 0xffff:
+		return append(b, 0xfd, byte(v), byte(v>>8))
+	case v <= 0xffffffff:
+		return append(b, 0xfe, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+	default:
+		b = append(b, 0xff)
+		for i := 0; i < 8; i++ {
+			b = append(b, byte(v>>(8*i)))
+		}
+		return b
+	}
+}
@@ -0,0 +1,230 @@
+package address_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+)
+
+func seq(n int, start byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = start + byte(i)
+	}
+	return b
+}
+
+func TestF4Jumble(t *testing.T) {
+	// First test vector of ZIP-316 (zcash-test-vectors f4jumble).
+	normal, _ := hex.DecodeString("5d7a8f739a2d9e945b0ce152a8049e294c4d6e66b164939daffa2ef6ee6921481cdd86b3cc4318d9614fc820905d042b")
+	jumbled, _ := hex.DecodeString("0304d029141b995da5387c125970673504d6c764d91ea6c082123770c7139ccd88ee27368cd0c0921a0444c8e5858d22")
+	got, err := address.F4Jumble(normal)
+	if err != nil {
+		t.Fatalf("F4Jumble: %v", err)
+	}
+	if !bytes.Equal(got, jumbled) {
+		t.Fatalf("F4Jumble=%x want %x", got, jumbled)
+	}
+	got, err = address.F4JumbleInv(jumbled)
+	if err != nil {
+		t.Fatalf("F4JumbleInv: %v", err)
+	}
+	if !bytes.Equal(got, normal) {
+		t.Fatalf("F4JumbleInv=%x want %x", got, normal)
+	}
+
+	for _, n := range []int{48, 64, 127, 128, 129, 1000} {
+		m := seq(n, 7)
+		j, err := address.F4Jumble(m)
+		if err != nil {
+			t.Fatalf("F4Jumble(%d): %v", n, err)
+		}
+		back, err := address.F4JumbleInv(j)
+		if err != nil {
+			t.Fatalf("F4JumbleInv(%d): %v", n, err)
+		}
+		if !bytes.Equal(back, m) {
+			t.Fatalf("round-trip mismatch for length %d", n)
+		}
+	}
+
+	if _, err := address.F4Jumble(make([]byte, 47)); err == nil {
+		t.Fatalf("expected length error")
+	}
+}
+
+func TestUnifiedAddress_RoundTrip(t *testing.T) {
+	for _, net := range []address.Network{address.Mainnet, address.Testnet, address.Regtest} {
+		ua := &address.UnifiedAddress{
+			Network: net,
+			Items: []address.Item{
+				{Typecode: address.TypecodeOrchard, Data: seq(address.OrchardReceiverSize, 1)},
+				{Typecode: address.TypecodeP2PKH, Data: seq(address.P2PKHReceiverSize, 100)},
+			},
+		}
+		s, err := ua.Encode()
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		if !strings.HasPrefix(s, net.AddressHRP()+"1") {
+			t.Fatalf("address %q missing hrp %q", s, net.AddressHRP())
+		}
+
+		got, err := address.ParseUnifiedAddress(s)
+		if err != nil {
+			t.Fatalf("ParseUnifiedAddress: %v", err)
+		}
+		if got.Network != net || len(got.Items) != 2 {
+			t.Fatalf("got=%+v", got)
+		}
+		// Items are encoded in ascending typecode order.
+		if got.Items[0].Typecode != address.TypecodeP2PKH {
+			t.Fatalf("items not sorted: %+v", got.Items)
+		}
+		orchard, ok := got.Orchard()
+		if !ok || !bytes.Equal(orchard[:], seq(address.OrchardReceiverSize, 1)) {
+			t.Fatalf("orchard receiver mismatch")
+		}
+		if _, ok := got.Sapling(); ok {
+			t.Fatalf("unexpected sapling receiver")
+		}
+		if err := address.ValidateAddressForNetwork(s, net); err != nil {
+			t.Fatalf("ValidateAddressForNetwork: %v", err)
+		}
+		if err := address.ValidateUFVK(s); err == nil {
+			t.Fatalf("an address must not validate as a ufvk")
+		}
+	}
+}
+
+func TestUnifiedAddress_Invalid(t *testing.T) {
+	orchard := address.Item{Typecode: address.TypecodeOrchard, Data: seq(address.OrchardReceiverSize, 1)}
+	tests := []struct {
+		name  string
+		items []address.Item
+	}{
+		{"empty", nil},
+		{"transparent only", []address.Item{{Typecode: address.TypecodeP2PKH, Data: seq(20, 0)}}},
+		{"p2pkh and p2sh", []address.Item{
+			{Typecode: address.TypecodeP2PKH, Data: seq(20, 0)},
+			{Typecode: address.TypecodeP2SH, Data: seq(20, 0)},
+			orchard,
+		}},
+		{"bad orchard length", []address.Item{{Typecode: address.TypecodeOrchard, Data: seq(42, 0)}}},
+		{"duplicate", []address.Item{orchard, orchard}},
+		{"must-understand metadata", []address.Item{orchard, {Typecode: 0xE0, Data: []byte{1}}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ua := &address.UnifiedAddress{Network: address.Regtest, Items: tc.items}
+			if _, err := ua.Encode(); !errors.Is(err, address.ErrInvalidEncoding) {
+				t.Fatalf("err=%v", err)
+			}
+		})
+	}
+
+	ua := &address.UnifiedAddress{Network: address.Regtest, Items: []address.Item{orchard}}
+	s, err := ua.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if err := address.ValidateAddressForNetwork(s, address.Mainnet); !errors.Is(err, address.ErrWrongNetwork) {
+		t.Fatalf("err=%v", err)
+	}
+
+	corrupted := []byte(s)
+	i := len(corrupted) / 2
+	if corrupted[i] == 'q' {
+		corrupted[i] = 'p'
+	} else {
+		corrupted[i] = 'q'
+	}
+	if err := address.ValidateAddress(string(corrupted)); err == nil {
+		t.Fatalf("expected checksum error")
+	}
+	if err := address.ValidateAddress("jtest1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqp4f3t7"); err == nil {
+		t.Fatalf("expected error for malformed address")
+	}
+	if err := address.ValidateAddress("zs1" + s[len("jregtest1"):]); err == nil {
+		t.Fatalf("expected hrp error")
+	}
+}
+
+func TestUnifiedAddress_UnknownTypecodeAllowed(t *testing.T) {
+	ua := &address.UnifiedAddress{
+		Network: address.Testnet,
+		Items: []address.Item{
+			{Typecode: address.TypecodeOrchard, Data: seq(address.OrchardReceiverSize, 1)},
+			{Typecode: 0x40, Data: []byte{1, 2, 3}},
+		},
+	}
+	s, err := ua.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := address.ParseUnifiedAddress(s)
+	if err != nil {
+		t.Fatalf("ParseUnifiedAddress: %v", err)
+	}
+	if len(got.Items) != 2 || got.Items[1].Typecode != 0x40 {
+		t.Fatalf("items=%+v", got.Items)
+	}
+}
+
+func TestUnifiedFullViewingKey_RoundTrip(t *testing.T) {
+	k := &address.UnifiedFullViewingKey{
+		Network: address.Mainnet,
+		Items: []address.Item{
+			{Typecode: address.TypecodeOrchard, Data: seq(address.OrchardFVKSize, 3)},
+		},
+	}
+	s, err := k.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if !strings.HasPrefix(s, address.MainnetUFVKHRP+"1") {
+		t.Fatalf("ufvk=%q", s)
+	}
+	got, err := address.ParseUnifiedFullViewingKey(strings.ToUpper(s))
+	if err != nil {
+		t.Fatalf("ParseUnifiedFullViewingKey: %v", err)
+	}
+	fvk, ok := got.Orchard()
+	if !ok || !bytes.Equal(fvk[:], seq(address.OrchardFVKSize, 3)) {
+		t.Fatalf("orchard fvk mismatch")
+	}
+	if err := address.ValidateAddress(s); err == nil {
+		t.Fatalf("a ufvk must not validate as an address")
+	}
+
+	bad := &address.UnifiedFullViewingKey{
+		Network: address.Mainnet,
+		Items: []address.Item{
+			{Typecode: address.TypecodeP2SH, Data: seq(20, 0)},
+			{Typecode: address.TypecodeOrchard, Data: seq(address.OrchardFVKSize, 3)},
+		},
+	}
+	if _, err := bad.Encode(); err == nil {
+		t.Fatalf("expected p2sh item error")
+	}
+}
+
+func TestParseNetwork(t *testing.T) {
+	for chain, want := range map[string]address.Network{
+		"main":    address.Mainnet,
+		"testnet": address.Testnet,
+		"regtest": address.Regtest,
+	} {
+		got, err := address.ParseNetwork(chain)
+		if err != nil || got != want {
+			t.Fatalf("ParseNetwork(%q)=%q, %v", chain, got, err)
+		}
+	}
+	if _, err := address.ParseNetwork("signet"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,331 @@
+// Package coinselect picks wallet notes to fund Orchard withdrawals and sweeps.
+package coinselect
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+type Strategy string
+
+const (
+	// LargestFirst spends the largest notes first, minimizing the number of actions.
+	LargestFirst Strategy = "largest_first"
+	// SmallestFirst spends the smallest notes first, consolidating dust.
+	SmallestFirst Strategy = "smallest_first"
+	// BranchAndBound searches for a set of notes that pays the target and fee without a change
+	// output, falling back to LargestFirst when none exists.
+	BranchAndBound Strategy = "branch_and_bound"
+	// All spends every eligible note (up to MaxActions), as for a sweep. Target is ignored.
+	All Strategy = "all"
+)
+
+const (
+	// DefaultMaxActions bounds the Orchard actions of a selection when Params.MaxActions is 0.
+	DefaultMaxActions = 50
+
+	bnbMaxTries = 100_000
+)
+
+// Params describes what a selection must pay for.
+type Params struct {
+	Strategy Strategy
+
+	// Target is the total of the transaction outputs, excluding change and fee.
+	Target types.Zatoshi
+	// Outputs is the number of outputs excluding change. Zero means 1.
+	Outputs int
+
+	// TipHeight and MinConfirmations filter out notes that are not yet spendable.
+	// Zero MinConfirmations means junoscan.DefaultMinConfirmations.
+	TipHeight        int64
+	MinConfirmations int64
+
+	// MaxActions limits the Orchard actions of the resulting transaction, including change.
+	// Zero means DefaultMaxActions.
+	MaxActions int
+
+	// ChangeTolerance is the largest excess BranchAndBound may pay as fee to avoid change.
+	// Zero means types.MarginalFee.
+	ChangeTolerance types.Zatoshi
+}
+
+// Selection is the result of Select.
+type Selection struct {
+	Notes  []junoscan.WalletNote
+	Total  types.Zatoshi
+	Target types.Zatoshi
+	Fee    types.Zatoshi
+	// Change is the value of the change output, or 0 if the transaction has none.
+	Change  types.Zatoshi
+	Actions int
+}
+
+var ErrNoEligibleNotes = errors.New("coinselect: no eligible notes")
+
+// Eligible returns the incoming notes that can be spent at tipHeight: unspent, without an active
+// pending spend, with a known position and at least minConfirmations confirmations.
+func Eligible(notes []junoscan.WalletNote, tipHeight, minConfirmations int64) []junoscan.WalletNote {
+	if minConfirmations <= 0 {
+		minConfirmations = junoscan.DefaultMinConfirmations
+	}
+	var out []junoscan.WalletNote
+	for _, n := range notes {
+		if n.Direction != "" && n.Direction != "incoming" {
+			continue
+		}
+		if n.SpentHeight != nil || (n.SpentTxID != nil && *n.SpentTxID != "") {
+			continue
+		}
+		if n.PendingSpendActive(tipHeight) || n.Position == nil || *n.Position < 0 {
+			continue
+		}
+		if n.ValueZat <= 0 || !types.Zatoshi(n.ValueZat).Valid() {
+			continue
+		}
+		if n.Confirmations(tipHeight) < minConfirmations {
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+// Select picks eligible notes according to p.Strategy. Shortfalls are reported as a
+// types.CodedError with ErrCodeInsufficientBalance.
+func Select(notes []junoscan.WalletNote, p Params) (Selection, error) {
+	if p.Outputs <= 0 {
+		p.Outputs = 1
+	}
+	if p.MaxActions <= 0 {
+		p.MaxActions = DefaultMaxActions
+	}
+	if p.ChangeTolerance <= 0 {
+		p.ChangeTolerance = types.MarginalFee
+	}
+	if p.Strategy == "" {
+		p.Strategy = LargestFirst
+	}
+	if p.Strategy != All && (p.Target <= 0 || !p.Target.Valid()) {
+		return Selection{}, fmt.Errorf("coinselect: invalid target %d", p.Target)
+	}
+	if p.Outputs > p.MaxActions {
+		return Selection{}, fmt.Errorf("coinselect: %d outputs exceed max actions %d", p.Outputs, p.MaxActions)
+	}
+
+	eligible := Eligible(notes, p.TipHeight, p.MinConfirmations)
+	if len(eligible) == 0 {
+		return Selection{}, insufficient("%v", ErrNoEligibleNotes)
+	}
+
+	switch p.Strategy {
+	case LargestFirst:
+		return accumulate(sortedByValue(eligible, true), p)
+	case SmallestFirst:
+		return accumulate(sortedByValue(eligible, false), p)
+	case BranchAndBound:
+		s, ok, err := branchAndBound(sortedByValue(eligible, true), p)
+		if err != nil || ok {
+			return s, err
+		}
+		return accumulate(sortedByValue(eligible, true), p)
+	case All:
+		return sweep(sortedByValue(eligible, true), p)
+	default:
+		return Selection{}, fmt.Errorf("coinselect: unknown strategy %q", p.Strategy)
+	}
+}
+
+// SpendNotes converts the selected notes into TxPlan spend notes with NoteID, ValueZat and
+// Position set. The action fields and Path are filled in by the planner from chain data.
+func (s Selection) SpendNotes() []types.OrchardSpendNote {
+	out := make([]types.OrchardSpendNote, 0, len(s.Notes))
+	for _, n := range s.Notes {
+		sn := types.OrchardSpendNote{
+			NoteID:   NoteID(n),
+			ValueZat: strconv.FormatInt(n.ValueZat, 10),
+		}
+		if n.Position != nil {
+			sn.Position = uint32(*n.Position)
+		}
+		out = append(out, sn)
+	}
+	return out
+}
+
+// NoteID returns the "txid:action_index" identifier used for OrchardSpendNote.NoteID.
+func NoteID(n junoscan.WalletNote) string {
+	return fmt.Sprintf("%s:%d", n.TxID, n.ActionIndex)
+}
+
+// finish computes fee and change for notes paying target with the given outputs. It reports
+// false if the notes do not cover the target plus the conventional fee, and an error if their
+// values are out of range.
+func finish(notes []junoscan.WalletNote, target types.Zatoshi, outputs int) (Selection, bool, error) {
+	total, err := junoscan.TotalValue(notes)
+	if err != nil {
+		return Selection{}, false, fmt.Errorf("coinselect: %w", err)
+	}
+	if total < target {
+		return Selection{}, false, nil
+	}
+	remainder := total - target
+	noChange := types.ConventionalFee(types.OrchardActions(len(notes), outputs))
+	withChange := types.ConventionalFee(types.OrchardActions(len(notes), outputs+1))
+
+	s := Selection{Notes: notes, Total: total, Target: target}
+	switch {
+	case remainder < noChange:
+		return Selection{}, false, nil
+	case remainder <= withChange:
+		// Too little left over to fund a change output: the excess is paid as fee.
+		s.Fee = remainder
+		s.Actions = types.OrchardActions(len(notes), outputs)
+	default:
+		s.Fee = withChange
+		s.Change = remainder - withChange
+		s.Actions = types.OrchardActions(len(notes), outputs+1)
+	}
+	return s, true, nil
+}
+
+func accumulate(sorted []junoscan.WalletNote, p Params) (Selection, error) {
+	var picked []junoscan.WalletNote
+	for _, n := range sorted {
+		if types.OrchardActions(len(picked)+1, p.Outputs) > p.MaxActions {
+			break
+		}
+		picked = append(picked, n)
+		s, ok, err := finish(picked, p.Target, p.Outputs)
+		if err != nil {
+			return Selection{}, err
+		}
+		if ok {
+			if s.Actions > p.MaxActions {
+				break
+			}
+			return s, nil
+		}
+	}
+	return Selection{}, insufficient("notes cannot fund %d plus fee within %d actions", p.Target, p.MaxActions)
+}
+
+func sweep(sorted []junoscan.WalletNote, p Params) (Selection, error) {
+	n := min(len(sorted), p.MaxActions)
+	picked := sorted[:n]
+	total, err := junoscan.TotalValue(picked)
+	if err != nil {
+		return Selection{}, fmt.Errorf("coinselect: %w", err)
+	}
+	fee := types.ConventionalFee(types.OrchardActions(n, p.Outputs))
+	if total <= fee {
+		return Selection{}, insufficient("notes total %d does not cover the fee %d", total, fee)
+	}
+	return Selection{
+		Notes:   slices.Clone(picked),
+		Total:   total,
+		Target:  total - fee,
+		Fee:     fee,
+		Actions: types.OrchardActions(n, p.Outputs),
+	}, nil
+}
+
+// branchAndBound searches depth-first over notes sorted by descending value for the set whose
+// excess over target+fee (without change) is smallest and small enough that no change output
+// is needed, up to p.ChangeTolerance.
+func branchAndBound(sorted []junoscan.WalletNote, p Params) (Selection, bool, error) {
+	// suffix[i] is the total of sorted[i:]. Every partial total in the search is at most
+	// suffix[0], so once that is in range the search can add values directly.
+	suffix := make([]types.Zatoshi, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		v, err := sorted[i].Value()
+		if err != nil {
+			return Selection{}, false, fmt.Errorf("coinselect: %w", err)
+		}
+		if suffix[i], err = suffix[i+1].Add(v); err != nil {
+			return Selection{}, false, fmt.Errorf("coinselect: notes total: %w", err)
+		}
+	}
+
+	var (
+		best      []int
+		bestWaste types.Zatoshi = -1
+		tries     int
+		cur       []int
+	)
+	var search func(i int, total types.Zatoshi)
+	search = func(i int, total types.Zatoshi) {
+		if tries++; tries > bnbMaxTries || bestWaste == 0 {
+			return
+		}
+		k := len(cur)
+		if k > 0 {
+			noChange := types.ConventionalFee(types.OrchardActions(k, p.Outputs))
+			need := p.Target + noChange
+			if total >= need {
+				// Beyond the cost of a change output the excess would be returned as change.
+				tolerance := min(p.ChangeTolerance, types.ConventionalFee(types.OrchardActions(k, p.Outputs+1))-noChange)
+				waste := total - need
+				if waste <= tolerance && (bestWaste < 0 || waste < bestWaste || (waste == bestWaste && k < len(best))) {
+					best, bestWaste = slices.Clone(cur), waste
+				}
+				// Adding notes only increases the excess.
+				return
+			}
+		}
+		if i >= len(sorted) || types.OrchardActions(k+1, p.Outputs) > p.MaxActions {
+			return
+		}
+		// Even taking every remaining note falls short.
+		maxNeed := p.Target + types.ConventionalFee(types.OrchardActions(k+1, p.Outputs))
+		if total+suffix[i] < maxNeed {
+			return
+		}
+		cur = append(cur, i)
+		search(i+1, total+types.Zatoshi(sorted[i].ValueZat))
+		cur = cur[:len(cur)-1]
+		search(i+1, total)
+	}
+	search(0, 0)
+
+	if bestWaste < 0 {
+		return Selection{}, false, nil
+	}
+	picked := make([]junoscan.WalletNote, len(best))
+	for j, i := range best {
+		picked[j] = sorted[i]
+	}
+	s, ok, err := finish(picked, p.Target, p.Outputs)
+	if err != nil || !ok || s.Change != 0 || s.Actions > p.MaxActions {
+		return Selection{}, false, err
+	}
+	return s, true, nil
+}
+
+func sortedByValue(notes []junoscan.WalletNote, desc bool) []junoscan.WalletNote {
+	out := slices.Clone(notes)
+	slices.SortStableFunc(out, func(a, b junoscan.WalletNote) int {
+		c := cmp.Compare(a.ValueZat, b.ValueZat)
+		if desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Compare(*a.Position, *b.Position)
+	})
+	return out
+}
+
+func insufficient(format string, args ...any) error {
+	return types.CodedError{
+		Code:    types.ErrCodeInsufficientBalance,
+		Message: "coinselect: " + fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,196 @@
+package coinselect_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/coinselect"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func note(pos int64, value int64) junoscan.WalletNote {
+	return junoscan.WalletNote{
+		TxID:     fmt.Sprintf("%064x", pos),
+		Height:   10,
+		Position: ptr(pos),
+		ValueZat: value,
+	}
+}
+
+func values(s coinselect.Selection) []int64 {
+	out := make([]int64, 0, len(s.Notes))
+	for _, n := range s.Notes {
+		out = append(out, n.ValueZat)
+	}
+	return out
+}
+
+func TestEligible(t *testing.T) {
+	notes := []junoscan.WalletNote{
+		note(0, 1000),
+		{TxID: "a", Height: 99, Position: ptr(int64(1)), ValueZat: 1000},                                                                        // too few confirmations
+		{TxID: "b", Height: 10, ValueZat: 1000},                                                                                                 // no position
+		{TxID: "c", Height: 10, Position: ptr(int64(3)), ValueZat: 1000, SpentHeight: ptr(int64(50))},                                           // spent
+		{TxID: "d", Height: 10, Position: ptr(int64(4)), ValueZat: 1000, PendingSpentTxID: ptr("p"), PendingSpentExpiryHeight: ptr(int64(200))}, // pending
+		{TxID: "e", Height: 10, Position: ptr(int64(5)), ValueZat: 1000, PendingSpentTxID: ptr("p"), PendingSpentExpiryHeight: ptr(int64(90))},  // pending spend expired
+		{TxID: "f", Height: 10, Position: ptr(int64(6)), ValueZat: 1000, Direction: "outgoing"},
+	}
+	got := coinselect.Eligible(notes, 100, 10)
+	if len(got) != 2 || got[0].TxID != notes[0].TxID || got[1].TxID != "e" {
+		t.Fatalf("eligible=%+v", got)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	wallet := []junoscan.WalletNote{
+		note(0, 1_000),
+		note(1, 3_000),
+		note(2, 40_000),
+		note(3, 25_000),
+		note(4, 200_000),
+		note(5, 2_000),
+	}
+
+	tests := []struct {
+		name       string
+		params     coinselect.Params
+		wantValues []int64
+		wantFee    types.Zatoshi
+		wantChange types.Zatoshi
+	}{
+		{
+			name:       "largest first",
+			params:     coinselect.Params{Strategy: coinselect.LargestFirst, Target: 50_000},
+			wantValues: []int64{200_000},
+			wantFee:    10_000,
+			wantChange: 140_000,
+		},
+		{
+			name:       "smallest first consolidates dust",
+			params:     coinselect.Params{Strategy: coinselect.SmallestFirst, Target: 20_000},
+			wantValues: []int64{1_000, 2_000, 3_000, 25_000, 40_000},
+			wantFee:    25_000,
+			wantChange: 26_000,
+		},
+		{
+			name:       "branch and bound finds exact match",
+			params:     coinselect.Params{Strategy: coinselect.BranchAndBound, Target: 55_000},
+			wantValues: []int64{40_000, 25_000},
+			wantFee:    10_000,
+		},
+		{
+			name:       "branch and bound pays small excess as fee",
+			params:     coinselect.Params{Strategy: coinselect.BranchAndBound, Target: 52_000, Outputs: 2},
+			wantValues: []int64{40_000, 25_000},
+			wantFee:    13_000,
+		},
+		{
+			name:       "branch and bound falls back to largest first",
+			params:     coinselect.Params{Strategy: coinselect.BranchAndBound, Target: 100_000},
+			wantValues: []int64{200_000},
+			wantFee:    10_000,
+			wantChange: 90_000,
+		},
+		{
+			name:       "sweep",
+			params:     coinselect.Params{Strategy: coinselect.All},
+			wantValues: []int64{200_000, 40_000, 25_000, 3_000, 2_000, 1_000},
+			wantFee:    30_000,
+		},
+		{
+			name:       "sweep limited by max actions",
+			params:     coinselect.Params{Strategy: coinselect.All, MaxActions: 3},
+			wantValues: []int64{200_000, 40_000, 25_000},
+			wantFee:    15_000,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.TipHeight = 100
+			s, err := coinselect.Select(wallet, tc.params)
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			if fmt.Sprint(values(s)) != fmt.Sprint(tc.wantValues) {
+				t.Fatalf("values=%v, want %v", values(s), tc.wantValues)
+			}
+			if s.Fee != tc.wantFee || s.Change != tc.wantChange {
+				t.Fatalf("fee=%d change=%d, want %d/%d", s.Fee, s.Change, tc.wantFee, tc.wantChange)
+			}
+			if s.Target+s.Fee+s.Change != s.Total {
+				t.Fatalf("unbalanced selection %+v", s)
+			}
+		})
+	}
+}
+
+func TestSelect_MatchesPlanFee(t *testing.T) {
+	wallet := []junoscan.WalletNote{note(0, 7_000), note(1, 9_000), note(2, 11_000), note(3, 60_000)}
+	for _, strategy := range []coinselect.Strategy{coinselect.LargestFirst, coinselect.SmallestFirst, coinselect.BranchAndBound} {
+		for target := types.Zatoshi(1_000); target <= 70_000; target += 3_500 {
+			s, err := coinselect.Select(wallet, coinselect.Params{Strategy: strategy, Target: target, Outputs: 2, TipHeight: 100})
+			if err != nil {
+				continue
+			}
+			plan := types.TxPlan{
+				Outputs: []types.TxOutput{{AmountZat: "1"}, {AmountZat: fmt.Sprint(int64(target) - 1)}},
+				Notes:   s.SpendNotes(),
+			}
+			plan, err = plan.WithConventionalFee()
+			if err != nil {
+				t.Fatalf("WithConventionalFee: %v", err)
+			}
+			if plan.FeeZat != fmt.Sprint(int64(s.Fee)) {
+				t.Fatalf("%s target %d: selection fee %d, plan fee %s", strategy, target, s.Fee, plan.FeeZat)
+			}
+		}
+	}
+}
+
+func TestSelect_Errors(t *testing.T) {
+	wallet := []junoscan.WalletNote{note(0, 10_000), note(1, 10_000), note(2, 10_000)}
+
+	_, err := coinselect.Select(wallet, coinselect.Params{Target: 25_000, TipHeight: 100})
+	var ce types.CodedError
+	if !errors.As(err, &ce) || ce.Code != types.ErrCodeInsufficientBalance {
+		t.Fatalf("err=%v, want insufficient balance", err)
+	}
+
+	_, err = coinselect.Select(wallet, coinselect.Params{Target: 5_000, TipHeight: 100, MaxActions: 1})
+	if err == nil {
+		t.Fatalf("expected max actions error")
+	}
+
+	_, err = coinselect.Select(wallet, coinselect.Params{Target: 5_000, TipHeight: 5})
+	if !errors.As(err, &ce) || ce.Code != types.ErrCodeInsufficientBalance {
+		t.Fatalf("err=%v, want insufficient balance for unconfirmed notes", err)
+	}
+}
+
+func TestSelect_TotalOutOfRange(t *testing.T) {
+	// Each note is a valid amount but together they exceed MaxMoney.
+	big := int64(types.MaxMoney) - 1
+	wallet := []junoscan.WalletNote{note(0, big), note(1, big), note(2, big)}
+
+	for _, strategy := range []coinselect.Strategy{coinselect.LargestFirst, coinselect.SmallestFirst, coinselect.BranchAndBound, coinselect.All} {
+		_, err := coinselect.Select(wallet, coinselect.Params{Strategy: strategy, Target: types.MaxMoney, TipHeight: 100})
+		if !errors.Is(err, types.ErrAmountOutOfRange) {
+			t.Fatalf("%s: err=%v, want ErrAmountOutOfRange", strategy, err)
+		}
+	}
+}
+
+func TestSelection_SpendNotes(t *testing.T) {
+	s, err := coinselect.Select([]junoscan.WalletNote{note(7, 50_000)}, coinselect.Params{Target: 10_000, TipHeight: 100})
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	got := s.SpendNotes()
+	if len(got) != 1 || got[0].Position != 7 || got[0].ValueZat != "50000" || got[0].NoteID != fmt.Sprintf("%064x:0", 7) {
+		t.Fatalf("spend notes=%+v", got)
+	}
+}
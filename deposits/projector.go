@@ -0,0 +1,445 @@
+// Package deposits folds juno-scan deposit events into per-deposit state.
+package deposits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+// Key identifies a deposit: one Orchard action of one transaction.
+type Key struct {
+	TxID        string `json:"txid"`
+	ActionIndex uint32 `json:"action_index"`
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%s:%d", k.TxID, k.ActionIndex)
+}
+
+// Deposit is the projected state of a deposit.
+type Deposit struct {
+	Key
+	WalletID string        `json:"wallet_id"`
+	Amount   types.Zatoshi `json:"amount"`
+	State    types.TxState `json:"state"`
+	Height   int64         `json:"height,omitempty"`
+
+	// Creditable is true while the deposit has reached its required confirmations and has
+	// not been rolled back or orphaned since.
+	Creditable      bool  `json:"creditable"`
+	ConfirmedHeight int64 `json:"confirmed_height,omitempty"`
+
+	// LastSeq is the highest event sequence number folded into this state.
+	LastSeq int64 `json:"last_seq"`
+	// Held is the number of events held back as illegal transitions (see Projector.Held).
+	Held int `json:"held,omitempty"`
+}
+
+type DeltaKind string
+
+const (
+	// DeltaNone means the event did not change what may be credited.
+	DeltaNone DeltaKind = "none"
+	// DeltaCredit means the deposit became creditable.
+	DeltaCredit DeltaKind = "credit"
+	// DeltaDebitUnconfirmed means a credited deposit fell below its required confirmations
+	// after a rollback and the credit must be reversed until it confirms again.
+	DeltaDebitUnconfirmed DeltaKind = "debit_unconfirmed"
+	// DeltaDebitOrphaned means a credited deposit was orphaned and must be debited back.
+	DeltaDebitOrphaned DeltaKind = "debit_orphaned"
+)
+
+// Delta is the effect one event had on the creditable balance.
+type Delta struct {
+	Kind    DeltaKind
+	Amount  types.Zatoshi
+	Deposit Deposit
+	// Duplicate is set when the event had already been applied.
+	Duplicate bool
+	// Held is set when the event is an illegal transition from the state of the earlier
+	// events and was held back. It is applied once the events missing before it arrive.
+	Held bool
+}
+
+var (
+	// ErrConflict is returned when an event disagrees with earlier events for the same deposit
+	// (amount, wallet) or reuses a sequence number with different content.
+	ErrConflict = errors.New("deposits: conflicting event")
+)
+
+// TransitionError describes an event held back because it would move a deposit through an
+// illegal types.TxState transition. See Projector.Held.
+type TransitionError struct {
+	Key  Key
+	Seq  int64
+	Kind types.WalletEventKind
+	From types.TxState
+	To   types.TxState
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("deposits: %s: illegal transition %s -> %s by %s (seq %d)", e.Key, stateName(e.From), stateName(e.To), e.Kind, e.Seq)
+}
+
+// Projector folds deposit events into per-deposit state. It is safe for concurrent use.
+//
+// Events carry a sequence number that orders them as juno-scan emitted them (the wallet event
+// id). The projector keeps the events of each deposit sorted by sequence and derives the state
+// by replaying them, so replays are no-ops and redelivery in any order converges on the same
+// final state. An event that is an illegal transition from the state of the events before it is
+// held back rather than rejected, since it may only look illegal because earlier events are yet
+// to arrive; every replay re-checks it. Deltas are computed against the state before each call;
+// their sum always equals the currently creditable amount.
+//
+// Once a deposit expires, which is terminal, and no events are held, its events are dropped and
+// only the state is kept. Other deposits keep their events until Prune compacts them: a
+// long-running consumer calls Prune with the cursor it has checkpointed, so that memory and the
+// cost of each Apply stay bounded. Events with a sequence at or below the compacted one that are
+// delivered later are duplicates.
+type Projector struct {
+	mu       sync.Mutex
+	deposits map[Key]*record
+}
+
+type record struct {
+	// base is the compacted state the events are replayed from.
+	base   Deposit
+	events []observation
+	state  Deposit
+	held   []*TransitionError
+}
+
+// observation is the part of a deposit event payload that drives the state machine.
+type observation struct {
+	seq             int64
+	kind            types.WalletEventKind
+	walletID        string
+	amount          types.Zatoshi
+	state           types.TxState
+	height          int64
+	confirmedHeight int64
+}
+
+func NewProjector() *Projector {
+	return &Projector{deposits: map[Key]*record{}}
+}
+
+// ApplyWalletEvent applies a juno-scan wallet event, using its id as the sequence number.
+// Non-deposit kinds are ignored.
+func (p *Projector) ApplyWalletEvent(e junoscan.WalletEvent) (Delta, error) {
+	return p.Apply(e.ID, e.Kind, e.Payload)
+}
+
+// Apply applies one event. seq must increase in the order juno-scan emitted the events
+// (e.g. WalletEvent.ID). Non-deposit kinds are ignored and return DeltaNone.
+func (p *Projector) Apply(seq int64, kind types.WalletEventKind, raw json.RawMessage) (Delta, error) {
+	switch kind {
+	case types.WalletEventKindDepositEvent, types.WalletEventKindDepositConfirmed,
+		types.WalletEventKindDepositUnconfirmed, types.WalletEventKindDepositOrphaned:
+	default:
+		if !kind.Known() {
+			return Delta{}, &types.UnknownKindError{Kind: kind}
+		}
+		return Delta{Kind: DeltaNone}, nil
+	}
+
+	payload, err := types.DecodePayload(kind, raw)
+	if err != nil {
+		return Delta{}, err
+	}
+	key, obs, err := observe(seq, kind, payload)
+	if err != nil {
+		return Delta{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rec := p.deposits[key]
+	if rec == nil {
+		rec = &record{}
+	}
+	before := rec.state
+	if seq <= rec.base.LastSeq {
+		return Delta{Kind: DeltaNone, Deposit: before, Duplicate: true}, nil
+	}
+
+	i, found := slices.BinarySearchFunc(rec.events, seq, func(o observation, s int64) int {
+		switch {
+		case o.seq < s:
+			return -1
+		case o.seq > s:
+			return 1
+		}
+		return 0
+	})
+	if found {
+		if rec.events[i] != obs {
+			return Delta{}, fmt.Errorf("%w: %s: seq %d redelivered with different content", ErrConflict, key, seq)
+		}
+		return Delta{Kind: DeltaNone, Deposit: before, Duplicate: true, Held: rec.isHeld(seq)}, nil
+	}
+
+	events := slices.Insert(slices.Clone(rec.events), i, obs)
+	after, held, err := replay(key, rec.base, events)
+	if err != nil {
+		return Delta{}, err
+	}
+	rec.events = events
+	rec.state = after
+	rec.held = held
+	if after.State == types.TxStateExpired && len(held) == 0 {
+		rec.base, rec.events = after, nil
+	}
+	p.deposits[key] = rec
+
+	out := delta(before, after)
+	out.Held = rec.isHeld(seq)
+	return out, nil
+}
+
+func (r *record) isHeld(seq int64) bool {
+	return slices.ContainsFunc(r.held, func(e *TransitionError) bool { return e.Seq == seq })
+}
+
+// Held returns the events currently held back as illegal transitions, ordered by deposit and
+// sequence. Events stay held until the events missing before them arrive; one that stays held
+// after the stream has caught up is a genuinely illegal transition.
+func (p *Projector) Held() []*TransitionError {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []*TransitionError
+	for _, rec := range p.deposits {
+		out = append(out, rec.held...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if c := strings.Compare(out[i].Key.TxID, out[j].Key.TxID); c != 0 {
+			return c < 0
+		}
+		if out[i].Key.ActionIndex != out[j].Key.ActionIndex {
+			return out[i].Key.ActionIndex < out[j].Key.ActionIndex
+		}
+		return out[i].Seq < out[j].Seq
+	})
+	return out
+}
+
+// Prune compacts the events of every deposit with a sequence number up to throughSeq into its
+// state. Call it once every event up to throughSeq has been applied, e.g. with the smallest
+// cursor a junoscan.EventFollower has saved for the wallets it follows. A held event and the
+// events after it are kept, so that they are still re-checked.
+func (p *Projector) Prune(throughSeq int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, rec := range p.deposits {
+		n := 0
+		for n < len(rec.events) && rec.events[n].seq <= throughSeq && !rec.isHeld(rec.events[n].seq) {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		// The prefix replayed without errors or held events as part of all the events.
+		base, _, _ := replay(key, rec.base, rec.events[:n])
+		rec.base = base
+		rec.events = slices.Clone(rec.events[n:])
+	}
+}
+
+// Deposit returns the projected state of one deposit.
+func (p *Projector) Deposit(k Key) (Deposit, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rec, ok := p.deposits[k]
+	if !ok {
+		return Deposit{}, false
+	}
+	return rec.state, true
+}
+
+// Deposits returns all projected deposits ordered by txid and action index.
+func (p *Projector) Deposits() []Deposit {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Deposit, 0, len(p.deposits))
+	for _, rec := range p.deposits {
+		out = append(out, rec.state)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if c := strings.Compare(out[i].TxID, out[j].TxID); c != 0 {
+			return c < 0
+		}
+		return out[i].ActionIndex < out[j].ActionIndex
+	})
+	return out
+}
+
+// Creditable returns the total amount of creditable deposits.
+func (p *Projector) Creditable() (types.Zatoshi, error) {
+	var vals []types.Zatoshi
+	for _, d := range p.Deposits() {
+		if d.Creditable {
+			vals = append(vals, d.Amount)
+		}
+	}
+	return types.SumZatoshi(vals...)
+}
+
+func observe(seq int64, kind types.WalletEventKind, payload any) (Key, observation, error) {
+	var (
+		ev  types.DepositEvent
+		obs = observation{seq: seq, kind: kind}
+	)
+	switch v := payload.(type) {
+	case types.DepositEventPayload:
+		ev = v.DepositEvent
+		obs.state = ev.Status.State
+		if obs.state == "" {
+			obs.state = types.TxStateMempool
+			if ev.Height > 0 {
+				obs.state = types.TxStateConfirmed
+			}
+		}
+	case types.DepositConfirmedPayload:
+		ev = v.DepositEvent
+		obs.state = types.TxStateConfirmed
+		obs.confirmedHeight = v.ConfirmedHeight
+	case types.DepositUnconfirmedPayload:
+		ev = v.DepositEvent
+		obs.state = ev.Status.State
+		if obs.state == "" {
+			obs.state = types.TxStateConfirmed
+		}
+	case types.DepositOrphanedPayload:
+		ev = v.DepositEvent
+		obs.state = types.TxStateOrphaned
+	default:
+		return Key{}, observation{}, fmt.Errorf("deposits: unexpected payload %T", payload)
+	}
+
+	if strings.TrimSpace(ev.TxID) == "" {
+		return Key{}, observation{}, fmt.Errorf("deposits: %s seq %d: txid required", kind, seq)
+	}
+	amount, err := ev.Amount()
+	if err != nil {
+		return Key{}, observation{}, fmt.Errorf("deposits: %s seq %d: %w", kind, seq, err)
+	}
+	obs.walletID = ev.WalletID
+	obs.amount = amount
+	obs.height = ev.Height
+	return Key{TxID: ev.TxID, ActionIndex: ev.ActionIndex}, obs, nil
+}
+
+// replay folds events into base, holding back the ones that are illegal transitions.
+func replay(key Key, base Deposit, events []observation) (Deposit, []*TransitionError, error) {
+	d := base
+	d.Key = key
+	d.Held = 0
+	known := base.LastSeq > 0
+	var held []*TransitionError
+	for _, o := range events {
+		if !known {
+			d.WalletID = o.walletID
+			d.Amount = o.amount
+			known = true
+		} else if o.walletID != d.WalletID || o.amount != d.Amount {
+			return Deposit{}, nil, fmt.Errorf("%w: %s: seq %d disagrees on wallet or amount", ErrConflict, key, o.seq)
+		}
+		if err := step(&d, o); err != nil {
+			var te *TransitionError
+			if !errors.As(err, &te) {
+				return Deposit{}, nil, err
+			}
+			held = append(held, te)
+			continue
+		}
+		d.LastSeq = o.seq
+	}
+	d.Held = len(held)
+	return d, held, nil
+}
+
+// step applies one observation to d, enforcing the deposit lifecycle. A deposit may start in
+// any state, since a consumer can begin mid-stream. After that:
+//   - expired is terminal;
+//   - an orphaned deposit must be detected again before it can confirm;
+//   - only a mined (confirmed) deposit can be rolled back below its confirmations;
+//   - a mined deposit can neither expire nor be re-announced as a mempool transaction
+//     without being orphaned or rolled back first.
+func step(d *Deposit, o observation) error {
+	from := d.State
+	illegal := func() error {
+		return &TransitionError{Key: d.Key, Seq: o.seq, Kind: o.kind, From: from, To: o.state}
+	}
+
+	switch {
+	case from == types.TxStateExpired && !(o.kind == types.WalletEventKindDepositEvent && o.state == types.TxStateExpired):
+		return illegal()
+	case from == "":
+	default:
+		switch o.kind {
+		case types.WalletEventKindDepositEvent:
+			if from == types.TxStateConfirmed && o.state != types.TxStateConfirmed && o.state != types.TxStateOrphaned {
+				return illegal()
+			}
+		case types.WalletEventKindDepositConfirmed:
+			if from == types.TxStateOrphaned {
+				return illegal()
+			}
+		case types.WalletEventKindDepositUnconfirmed:
+			if from != types.TxStateConfirmed {
+				return illegal()
+			}
+		case types.WalletEventKindDepositOrphaned:
+		}
+	}
+
+	d.State = o.state
+	d.Height = o.height
+	switch o.kind {
+	case types.WalletEventKindDepositConfirmed:
+		d.Creditable = true
+		d.ConfirmedHeight = o.confirmedHeight
+	case types.WalletEventKindDepositEvent:
+		// A re-announcement of a mined deposit keeps an existing credit.
+		if o.state != types.TxStateConfirmed {
+			d.Creditable = false
+			d.ConfirmedHeight = 0
+		}
+	default:
+		d.Creditable = false
+		d.ConfirmedHeight = 0
+	}
+	return nil
+}
+
+func delta(before, after Deposit) Delta {
+	out := Delta{Kind: DeltaNone, Deposit: after}
+	switch {
+	case !before.Creditable && after.Creditable:
+		out.Kind = DeltaCredit
+		out.Amount = after.Amount
+	case before.Creditable && !after.Creditable:
+		out.Amount = before.Amount
+		out.Kind = DeltaDebitUnconfirmed
+		if after.State == types.TxStateOrphaned {
+			out.Kind = DeltaDebitOrphaned
+		}
+	}
+	return out
+}
+
+func stateName(s types.TxState) string {
+	if s == "" {
+		return "new"
+	}
+	return string(s)
+}
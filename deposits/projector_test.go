@@ -0,0 +1,342 @@
+package deposits_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/deposits"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+const testTxID = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+func depositPayload(state types.TxState, height int64) types.DepositEventPayload {
+	return types.DepositEventPayload{DepositEvent: types.DepositEvent{
+		Version:        types.V1,
+		WalletID:       "hot",
+		TxID:           testTxID,
+		Height:         height,
+		ActionIndex:    1,
+		AmountZatoshis: 5000,
+		Status:         types.TxStatus{State: state, Height: height},
+	}}
+}
+
+func walletEvent(t *testing.T, id int64, kind types.WalletEventKind, payload any) junoscan.WalletEvent {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return junoscan.WalletEvent{ID: id, Kind: kind, Payload: raw}
+}
+
+// lifecycle is a deposit that confirms, is rolled back, confirms again, is orphaned by a reorg
+// and is finally mined again in the new chain.
+func lifecycle(t *testing.T) []junoscan.WalletEvent {
+	mined := depositPayload(types.TxStateConfirmed, 100)
+	remined := depositPayload(types.TxStateConfirmed, 105)
+	return []junoscan.WalletEvent{
+		walletEvent(t, 1, types.WalletEventKindDepositEvent, depositPayload(types.TxStateMempool, 0)),
+		walletEvent(t, 2, types.WalletEventKindDepositEvent, mined),
+		walletEvent(t, 3, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{DepositEventPayload: mined, ConfirmedHeight: 109, RequiredConfirmations: 10}),
+		walletEvent(t, 4, types.WalletEventKindDepositUnconfirmed, types.DepositUnconfirmedPayload{DepositEventPayload: mined, RollbackHeight: 108, PreviousConfirmedHeight: 109}),
+		walletEvent(t, 5, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{DepositEventPayload: mined, ConfirmedHeight: 109, RequiredConfirmations: 10}),
+		walletEvent(t, 7, types.WalletEventKindSpendEvent, types.SpendEventPayload{TxID: "bb"}),
+		walletEvent(t, 8, types.WalletEventKindDepositOrphaned, types.DepositOrphanedPayload{DepositEventPayload: mined, OrphanedAtHeight: 100}),
+		walletEvent(t, 9, types.WalletEventKindDepositEvent, remined),
+		walletEvent(t, 12, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{DepositEventPayload: remined, ConfirmedHeight: 114, RequiredConfirmations: 10}),
+	}
+}
+
+func signed(d deposits.Delta) int64 {
+	switch d.Kind {
+	case deposits.DeltaCredit:
+		return int64(d.Amount)
+	case deposits.DeltaDebitOrphaned, deposits.DeltaDebitUnconfirmed:
+		return -int64(d.Amount)
+	}
+	return 0
+}
+
+func TestProjector_InOrderDeltas(t *testing.T) {
+	p := deposits.NewProjector()
+	want := []deposits.DeltaKind{
+		deposits.DeltaNone,
+		deposits.DeltaNone,
+		deposits.DeltaCredit,
+		deposits.DeltaDebitUnconfirmed,
+		deposits.DeltaCredit,
+		deposits.DeltaNone,
+		deposits.DeltaDebitOrphaned,
+		deposits.DeltaNone,
+		deposits.DeltaCredit,
+	}
+	for i, e := range lifecycle(t) {
+		d, err := p.ApplyWalletEvent(e)
+		if err != nil {
+			t.Fatalf("event %d: %v", e.ID, err)
+		}
+		if d.Kind != want[i] {
+			t.Fatalf("event %d: delta=%s, want %s", e.ID, d.Kind, want[i])
+		}
+		if d.Kind != deposits.DeltaNone && d.Amount != 5000 {
+			t.Fatalf("event %d: amount=%d", e.ID, d.Amount)
+		}
+	}
+
+	got, ok := p.Deposit(deposits.Key{TxID: testTxID, ActionIndex: 1})
+	if !ok {
+		t.Fatalf("deposit missing")
+	}
+	if got.State != types.TxStateConfirmed || !got.Creditable || got.Height != 105 || got.ConfirmedHeight != 114 || got.LastSeq != 12 {
+		t.Fatalf("deposit=%+v", got)
+	}
+	if total, err := p.Creditable(); err != nil || total != 5000 {
+		t.Fatalf("Creditable=%d, %v", total, err)
+	}
+}
+
+func TestProjector_ReplayIsIdempotent(t *testing.T) {
+	p := deposits.NewProjector()
+	events := lifecycle(t)
+	for _, e := range events {
+		if _, err := p.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+	for _, e := range events {
+		d, err := p.ApplyWalletEvent(e)
+		if err != nil {
+			t.Fatalf("replay %d: %v", e.ID, err)
+		}
+		if d.Kind != deposits.DeltaNone {
+			t.Fatalf("replay %d: delta=%s", e.ID, d.Kind)
+		}
+	}
+	if total, _ := p.Creditable(); total != 5000 {
+		t.Fatalf("Creditable=%d", total)
+	}
+}
+
+func TestProjector_OutOfOrderConverges(t *testing.T) {
+	ref := deposits.NewProjector()
+	events := lifecycle(t)
+	for _, e := range events {
+		if _, err := ref.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+	want := ref.Deposits()
+
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		shuffled := append([]junoscan.WalletEvent(nil), events...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		p := deposits.NewProjector()
+		var net int64
+		for _, e := range shuffled {
+			d, err := p.ApplyWalletEvent(e)
+			if err != nil {
+				t.Fatalf("trial %d event %d: %v", trial, e.ID, err)
+			}
+			net += signed(d)
+		}
+
+		got := p.Deposits()
+		if len(got) != len(want) || got[0] != want[0] {
+			t.Fatalf("trial %d: got %+v, want %+v", trial, got, want)
+		}
+		if held := p.Held(); len(held) != 0 {
+			t.Fatalf("trial %d: held %v after all events arrived", trial, held)
+		}
+		if net != 5000 {
+			t.Fatalf("trial %d: net delta=%d, want 5000", trial, net)
+		}
+	}
+}
+
+func TestProjector_HoldsEventUntilGapFills(t *testing.T) {
+	events := lifecycle(t)
+	p := deposits.NewProjector()
+	key := deposits.Key{TxID: testTxID, ActionIndex: 1}
+
+	// The rollback (seq 4) arrives right after the mempool event, before the events that mined
+	// and confirmed the deposit.
+	for _, e := range []junoscan.WalletEvent{events[0], events[3]} {
+		if _, err := p.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply %d: %v", e.ID, err)
+		}
+	}
+	held := p.Held()
+	if len(held) != 1 || held[0].Seq != 4 || held[0].From != types.TxStateMempool {
+		t.Fatalf("held=%v", held)
+	}
+	if d, _ := p.Deposit(key); d.State != types.TxStateMempool || d.Held != 1 || d.LastSeq != 1 {
+		t.Fatalf("deposit=%+v", d)
+	}
+	if d, err := p.ApplyWalletEvent(events[3]); err != nil || !d.Duplicate || !d.Held {
+		t.Fatalf("redelivery=%+v err=%v", d, err)
+	}
+
+	if _, err := p.ApplyWalletEvent(events[1]); err != nil {
+		t.Fatalf("apply 2: %v", err)
+	}
+	d, err := p.ApplyWalletEvent(events[2])
+	if err != nil {
+		t.Fatalf("apply 3: %v", err)
+	}
+	// Confirming and then rolling back leaves nothing to credit.
+	if d.Kind != deposits.DeltaNone || d.Deposit.State != types.TxStateConfirmed || d.Deposit.Creditable || d.Deposit.LastSeq != 4 {
+		t.Fatalf("delta=%+v", d)
+	}
+	if held := p.Held(); len(held) != 0 {
+		t.Fatalf("held=%v after the gap filled", held)
+	}
+}
+
+func TestProjector_CompactsExpiredDeposits(t *testing.T) {
+	p := deposits.NewProjector()
+	key := deposits.Key{TxID: testTxID, ActionIndex: 1}
+	mempool := walletEvent(t, 1, types.WalletEventKindDepositEvent, depositPayload(types.TxStateMempool, 0))
+	expired := walletEvent(t, 2, types.WalletEventKindDepositEvent, depositPayload(types.TxStateExpired, 0))
+	for _, e := range []junoscan.WalletEvent{mempool, expired} {
+		if _, err := p.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply %d: %v", e.ID, err)
+		}
+	}
+	want, _ := p.Deposit(key)
+	if want.State != types.TxStateExpired || want.LastSeq != 2 {
+		t.Fatalf("deposit=%+v", want)
+	}
+
+	for _, e := range []junoscan.WalletEvent{mempool, expired} {
+		d, err := p.ApplyWalletEvent(e)
+		if err != nil || !d.Duplicate || d.Kind != deposits.DeltaNone {
+			t.Fatalf("replay %d after compaction: %+v err=%v", e.ID, d, err)
+		}
+	}
+	mined := depositPayload(types.TxStateConfirmed, 100)
+	d, err := p.ApplyWalletEvent(walletEvent(t, 3, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{DepositEventPayload: mined}))
+	if err != nil || !d.Held {
+		t.Fatalf("confirm after expiry: %+v err=%v", d, err)
+	}
+	if got, _ := p.Deposit(key); got.State != types.TxStateExpired || got.Held != 1 {
+		t.Fatalf("deposit=%+v", got)
+	}
+}
+
+func TestProjector_Prune(t *testing.T) {
+	events := lifecycle(t)
+	p := deposits.NewProjector()
+	key := deposits.Key{TxID: testTxID, ActionIndex: 1}
+	for _, e := range events[:5] {
+		if _, err := p.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply %d: %v", e.ID, err)
+		}
+	}
+	want, _ := p.Deposit(key)
+	p.Prune(5)
+	if got, _ := p.Deposit(key); got != want || !got.Creditable || got.LastSeq != 5 {
+		t.Fatalf("deposit=%+v, want %+v", got, want)
+	}
+	if d, err := p.ApplyWalletEvent(events[2]); err != nil || !d.Duplicate || d.Kind != deposits.DeltaNone {
+		t.Fatalf("replay after prune: %+v err=%v", d, err)
+	}
+	d, err := p.ApplyWalletEvent(events[6])
+	if err != nil || d.Kind != deposits.DeltaDebitOrphaned || d.Deposit.LastSeq != 8 {
+		t.Fatalf("orphan after prune: %+v err=%v", d, err)
+	}
+
+	// A held event survives pruning and is still applied once the gap fills.
+	p = deposits.NewProjector()
+	for _, e := range []junoscan.WalletEvent{events[0], events[3]} {
+		if _, err := p.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply %d: %v", e.ID, err)
+		}
+	}
+	p.Prune(4)
+	if held := p.Held(); len(held) != 1 || held[0].Seq != 4 {
+		t.Fatalf("held=%v after prune", held)
+	}
+	for _, e := range events[1:3] {
+		if _, err := p.ApplyWalletEvent(e); err != nil {
+			t.Fatalf("apply %d: %v", e.ID, err)
+		}
+	}
+	if got, _ := p.Deposit(key); got.Held != 0 || got.LastSeq != 4 || got.Creditable {
+		t.Fatalf("deposit=%+v after the gap filled", got)
+	}
+}
+
+func TestProjector_IllegalTransitions(t *testing.T) {
+	expired := depositPayload(types.TxStateExpired, 0)
+	mined := depositPayload(types.TxStateConfirmed, 100)
+
+	tests := []struct {
+		name   string
+		events []junoscan.WalletEvent
+	}{
+		{"confirm after expiry", []junoscan.WalletEvent{
+			walletEvent(t, 1, types.WalletEventKindDepositEvent, expired),
+			walletEvent(t, 2, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{DepositEventPayload: mined}),
+		}},
+		{"confirm after orphan", []junoscan.WalletEvent{
+			walletEvent(t, 1, types.WalletEventKindDepositOrphaned, types.DepositOrphanedPayload{DepositEventPayload: mined}),
+			walletEvent(t, 2, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{DepositEventPayload: mined}),
+		}},
+		{"unconfirm from mempool", []junoscan.WalletEvent{
+			walletEvent(t, 1, types.WalletEventKindDepositEvent, depositPayload(types.TxStateMempool, 0)),
+			walletEvent(t, 2, types.WalletEventKindDepositUnconfirmed, types.DepositUnconfirmedPayload{DepositEventPayload: mined}),
+		}},
+		{"mined deposit expires", []junoscan.WalletEvent{
+			walletEvent(t, 1, types.WalletEventKindDepositEvent, mined),
+			walletEvent(t, 2, types.WalletEventKindDepositEvent, expired),
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := deposits.NewProjector()
+			if _, err := p.ApplyWalletEvent(tc.events[0]); err != nil {
+				t.Fatalf("first event: %v", err)
+			}
+			before, _ := p.Deposit(deposits.Key{TxID: testTxID, ActionIndex: 1})
+			d, err := p.ApplyWalletEvent(tc.events[1])
+			if err != nil || !d.Held || d.Kind != deposits.DeltaNone {
+				t.Fatalf("delta=%+v err=%v, want held", d, err)
+			}
+			held := p.Held()
+			if len(held) != 1 || held[0].Seq != 2 {
+				t.Fatalf("held=%v", held)
+			}
+			after, _ := p.Deposit(deposits.Key{TxID: testTxID, ActionIndex: 1})
+			before.Held = 1
+			if before != after {
+				t.Fatalf("state changed by held event: %+v -> %+v", before, after)
+			}
+		})
+	}
+}
+
+func TestProjector_Conflicts(t *testing.T) {
+	p := deposits.NewProjector()
+	if _, err := p.ApplyWalletEvent(walletEvent(t, 1, types.WalletEventKindDepositEvent, depositPayload(types.TxStateMempool, 0))); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	other := depositPayload(types.TxStateMempool, 0)
+	other.AmountZatoshis = 1
+	if _, err := p.ApplyWalletEvent(walletEvent(t, 2, types.WalletEventKindDepositEvent, other)); !errors.Is(err, deposits.ErrConflict) {
+		t.Fatalf("amount mismatch: err=%v", err)
+	}
+	if _, err := p.ApplyWalletEvent(walletEvent(t, 1, types.WalletEventKindDepositEvent, depositPayload(types.TxStateConfirmed, 100))); !errors.Is(err, deposits.ErrConflict) {
+		t.Fatalf("seq reuse: err=%v", err)
+	}
+	if _, err := p.Apply(3, "Mystery", json.RawMessage(`{}`)); err == nil {
+		t.Fatalf("expected unknown kind error")
+	}
+}
@@ -0,0 +1,76 @@
+package blake2b_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/internal/blake2b"
+)
+
+func TestSum(t *testing.T) {
+	long := make([]byte, 0, 768)
+	for i := 0; i < 3; i++ {
+		for b := 0; b < 256; b++ {
+			long = append(long, byte(b))
+		}
+	}
+
+	tests := []struct {
+		name     string
+		size     int
+		personal string
+		data     []byte
+		want     string
+	}{
+		{
+			name: "abc-512",
+			size: 64,
+			data: []byte("abc"),
+			want: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923",
+		},
+		{
+			name: "abc-256",
+			size: 32,
+			data: []byte("abc"),
+			want: "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319",
+		},
+		{
+			name:     "personalized",
+			size:     32,
+			personal: "ZTxIdHeadersHash",
+			data:     []byte("abc"),
+			want:     "b3270eee3d6f04890d9b52c2612a1268129b57153001b1e5ef36819b3149631b",
+		},
+		{
+			name:     "personalized-empty",
+			size:     32,
+			personal: "ZcashTxHash_\xf0\x4d\xec\x4d",
+			want:     "a83b85d7b3b290191d7c370b7565077ed9c59c2cada0ee70ec30df7fc5ed91aa",
+		},
+		{
+			name:     "short-personal-multiblock",
+			size:     64,
+			personal: "UA_F4Jumble_H",
+			data:     long,
+			want:     "4666e1895c840f303bb6c948f4b47875d870c4838f47fabbab432ea1b226c17b38c251520bfe706c2bcba6ccf4fff41c274fdb3f1f5167a1af47585da069d448",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := hex.EncodeToString(blake2b.Sum(tc.size, []byte(tc.personal), tc.data))
+			if got != tc.want {
+				t.Fatalf("got  %s\nwant %s", got, tc.want)
+			}
+
+			// Feeding the same input in uneven pieces must not change the digest.
+			h := blake2b.New(tc.size, []byte(tc.personal))
+			for i := 0; i < len(tc.data); i += 37 {
+				h.Write(tc.data[i:min(i+37, len(tc.data))])
+			}
+			if got := hex.EncodeToString(h.Sum(nil)); got != tc.want {
+				t.Fatalf("incremental got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package junobroadcasttest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Abdullah1738/juno-sdk-go/junobroadcast"
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+)
+
+// apiError is an error response in the juno-broadcast format.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, junobroadcast.HealthResponse{Status: "ok"})
+	})
+	mux.HandleFunc("POST /v1/tx/submit", s.handleSubmit)
+	mux.HandleFunc("GET /v1/tx/{txid}", s.handleStatus)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		s.mu.Lock()
+		queue := s.failures[pattern]
+		var f *failure
+		if len(queue) > 0 {
+			f = &queue[0]
+			s.failures[pattern] = queue[1:]
+		}
+		s.mu.Unlock()
+		if f != nil {
+			writeError(w, &apiError{status: f.status, code: f.code, message: f.message})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req junobroadcast.SubmitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "invalid json"})
+		return
+	}
+	raw := strings.TrimSpace(req.RawTxHex)
+	if raw == "" {
+		writeError(w, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "raw_tx_hex required"})
+		return
+	}
+	if req.WaitConfirmations != nil && *req.WaitConfirmations < 0 {
+		writeError(w, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "wait_confirmations must be >= 0"})
+		return
+	}
+
+	txid, aerr := s.submit(r.Context(), raw)
+	if aerr != nil {
+		writeError(w, aerr)
+		return
+	}
+	resp := junobroadcast.SubmitResponse{TxID: txid}
+	if req.WaitConfirmations != nil {
+		st, aerr := s.wait(r.Context(), txid, *req.WaitConfirmations)
+		if aerr != nil {
+			writeError(w, aerr)
+			return
+		}
+		resp.Status = &st
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	txid := strings.ToLower(r.PathValue("txid"))
+	st, found, aerr := s.status(r.Context(), txid)
+	if aerr != nil {
+		writeError(w, aerr)
+		return
+	}
+	if !found {
+		writeError(w, &apiError{status: http.StatusNotFound, code: "not_found", message: "unknown txid"})
+		return
+	}
+	writeJSON(w, st)
+}
+
+// nodeError maps a junocashd error to a juno-broadcast error response.
+func nodeError(err error) *apiError {
+	var rpcErr *junocashd.RPCError
+	if !errors.As(err, &rpcErr) {
+		return &apiError{status: http.StatusBadGateway, code: "node_unavailable", message: err.Error()}
+	}
+	switch rpcErr.Code {
+	case -22:
+		return &apiError{status: http.StatusBadRequest, code: "invalid_request", message: rpcErr.Message}
+	case -25, -26:
+		return &apiError{status: http.StatusUnprocessableEntity, code: "rejected", message: rpcErr.Message}
+	default:
+		return &apiError{status: http.StatusBadGateway, code: "node_error", message: rpcErr.Message}
+	}
+}
+
+func isNotFound(err error) bool {
+	var rpcErr *junocashd.RPCError
+	return errors.As(err, &rpcErr) && rpcErr.Code == -5
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]string{"code": e.code, "message": e.message},
+	})
+}
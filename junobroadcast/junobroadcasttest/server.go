@@ -0,0 +1,311 @@
+// Package junobroadcasttest provides an in-memory juno-broadcast server for tests.
+//
+// By default the server simulates a chain: submitted transactions wait in a mempool until test
+// code calls Mine, and /v1/tx/{txid} reports their confirmations from then on. A submit with
+// wait_confirmations blocks until the transaction has that many confirmations, so a test can
+// mine from another goroutine and observe the wait complete deterministically. With WithNode,
+// the server forwards to a junocashd node instead (for example a junocashdtest server or a
+// regtest node) and reports what the node reports.
+//
+// The server serves these routes, which are also the keys of FailNext:
+//
+//	GET  /healthz
+//	POST /v1/tx/submit
+//	GET  /v1/tx/{txid}
+package junobroadcasttest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junobroadcast"
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+)
+
+const (
+	defaultWaitTimeout  = 30 * time.Second
+	defaultPollInterval = 50 * time.Millisecond
+)
+
+// Server is an in-memory juno-broadcast. Its methods are safe for concurrent use with HTTP
+// traffic.
+type Server struct {
+	// URL is the base URL, for junobroadcast.New.
+	URL string
+
+	srv          *httptest.Server
+	node         *junocashd.Client
+	waitTimeout  time.Duration
+	pollInterval time.Duration
+
+	mu sync.Mutex
+	// blocks holds the simulated best chain, as hashes indexed by height.
+	blocks []string
+	txs    map[string]*simTx
+	nonce  uint64
+	// changed is closed and replaced whenever the simulated chain or mempool changes.
+	changed  chan struct{}
+	failures map[string][]failure
+}
+
+type simTx struct {
+	txid string
+	// height is the height of the including block, or -1 while in the mempool.
+	height int64
+}
+
+type failure struct {
+	status  int
+	code    string
+	message string
+}
+
+type Option func(*Server)
+
+// WithNode makes the server forward submissions to node (sendrawtransaction) and answer status
+// requests from it (getrawtransaction), instead of simulating a chain. Mine, Evict and Reorg
+// then have no effect on what the server reports.
+func WithNode(node *junocashd.Client) Option {
+	return func(s *Server) {
+		s.node = node
+	}
+}
+
+// WithWaitTimeout bounds how long a submit with wait_confirmations blocks before failing with
+// the "timeout" error code. The default is 30s.
+func WithWaitTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.waitTimeout = d
+		}
+	}
+}
+
+// WithPollInterval sets how often a waiting submit polls the node in WithNode mode. The default
+// is 50ms.
+func WithPollInterval(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.pollInterval = d
+		}
+	}
+}
+
+// NewServer starts a server whose simulated chain is at height 0. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		waitTimeout:  defaultWaitTimeout,
+		pollInterval: defaultPollInterval,
+		txs:          map[string]*simTx{},
+		changed:      make(chan struct{}),
+		failures:     map[string][]failure{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	s.blocks = []string{s.blockHash(0)}
+	s.srv = httptest.NewServer(s.routes())
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() { s.srv.Close() }
+
+// Client returns a junobroadcast.Client for the server.
+func (s *Server) Client(opts ...junobroadcast.Option) *junobroadcast.Client {
+	c, err := junobroadcast.New(s.URL, opts...)
+	if err != nil {
+		panic(err) // the httptest URL is always valid
+	}
+	return c
+}
+
+// Height returns the height of the simulated chain.
+func (s *Server) Height() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.blocks) - 1)
+}
+
+// Mine advances the simulated chain by n blocks. The first block confirms every transaction in
+// the mempool; each block adds a confirmation to the transactions already mined.
+func (s *Server) Mine(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for range n {
+		height := int64(len(s.blocks))
+		s.blocks = append(s.blocks, s.blockHash(height))
+		for _, tx := range s.txs {
+			if tx.height < 0 {
+				tx.height = height
+			}
+		}
+	}
+	s.notify()
+}
+
+// Reorg disconnects the top depth blocks of the simulated chain. Transactions mined in them
+// return to the mempool; mine again to confirm them in new blocks.
+func (s *Server) Reorg(depth int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if depth < 1 || depth >= len(s.blocks) {
+		return fmt.Errorf("junobroadcasttest: reorg depth %d out of range 1..%d", depth, len(s.blocks)-1)
+	}
+	s.blocks = s.blocks[:len(s.blocks)-depth]
+	for _, tx := range s.txs {
+		if tx.height >= int64(len(s.blocks)) {
+			tx.height = -1
+		}
+	}
+	s.notify()
+	return nil
+}
+
+// Evict forgets a transaction, as if it had been dropped from the mempool or never sent, so
+// that /v1/tx/{txid} returns not_found. It reports whether the transaction was known.
+func (s *Server) Evict(txid string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txid = strings.ToLower(txid)
+	_, ok := s.txs[txid]
+	delete(s.txs, txid)
+	s.notify()
+	return ok
+}
+
+// Transactions returns the txids submitted to the simulated chain and not evicted.
+func (s *Server) Transactions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, 0, len(s.txs))
+	for txid := range s.txs {
+		out = append(out, txid)
+	}
+	return out
+}
+
+// FailNext makes the next request to route fail with the HTTP status and an error body carrying
+// code and message, which junobroadcast.APIError parses. route is one of the patterns listed in
+// the package documentation. Failures queue up per route.
+func (s *Server) FailNext(route string, status int, code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[route] = append(s.failures[route], failure{status: status, code: code, message: message})
+}
+
+// submit accepts a raw transaction and returns its txid.
+func (s *Server) submit(ctx context.Context, rawTxHex string) (string, *apiError) {
+	if s.node != nil {
+		txid, err := s.node.SendRawTransaction(ctx, rawTxHex)
+		var rpcErr *junocashd.RPCError
+		if errors.As(err, &rpcErr) && rpcErr.Code == -27 {
+			// Already mined: resubmitting is not an error.
+			if tx, perr := junotx.ParseTransactionHex(rawTxHex); perr == nil {
+				return tx.TxID(), nil
+			}
+		}
+		if err != nil {
+			return "", nodeError(err)
+		}
+		return strings.ToLower(txid), nil
+	}
+
+	tx, err := junotx.ParseTransactionHex(rawTxHex)
+	if err != nil {
+		return "", &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "invalid raw_tx_hex: " + err.Error()}
+	}
+	txid := tx.TxID()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.txs[txid]; !ok {
+		s.txs[txid] = &simTx{txid: txid, height: -1}
+		s.notify()
+	}
+	return txid, nil
+}
+
+// status returns the status of txid, or false if it is unknown.
+func (s *Server) status(ctx context.Context, txid string) (junobroadcast.TxStatus, bool, *apiError) {
+	if s.node != nil {
+		tx, err := s.node.GetRawTransactionVerbose(ctx, txid)
+		if err != nil {
+			if isNotFound(err) {
+				return junobroadcast.TxStatus{}, false, nil
+			}
+			return junobroadcast.TxStatus{}, false, nodeError(err)
+		}
+		st := junobroadcast.TxStatus{TxID: txid, Confirmations: tx.Confirmations, BlockHash: tx.BlockHash}
+		st.InMempool = tx.BlockHash == ""
+		return st, true, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.txs[txid]
+	if !ok {
+		return junobroadcast.TxStatus{}, false, nil
+	}
+	if tx.height < 0 {
+		return junobroadcast.TxStatus{TxID: txid, InMempool: true}, true, nil
+	}
+	return junobroadcast.TxStatus{
+		TxID:          txid,
+		Confirmations: int64(len(s.blocks)) - tx.height,
+		BlockHash:     s.blocks[tx.height],
+	}, true, nil
+}
+
+// wait blocks until txid has at least confirmations confirmations.
+func (s *Server) wait(ctx context.Context, txid string, confirmations int64) (junobroadcast.TxStatus, *apiError) {
+	ctx, cancel := context.WithTimeout(ctx, s.waitTimeout)
+	defer cancel()
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		s.mu.Lock()
+		changed := s.changed
+		s.mu.Unlock()
+
+		st, found, aerr := s.status(ctx, txid)
+		if aerr != nil {
+			return junobroadcast.TxStatus{}, aerr
+		}
+		if !found {
+			return junobroadcast.TxStatus{}, &apiError{status: http.StatusConflict, code: "dropped", message: "transaction left the mempool"}
+		}
+		if st.Confirmations >= confirmations {
+			return st, nil
+		}
+		select {
+		case <-ctx.Done():
+			return junobroadcast.TxStatus{}, &apiError{status: http.StatusGatewayTimeout, code: "timeout", message: fmt.Sprintf("%d of %d confirmations", st.Confirmations, confirmations)}
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify wakes up waiting submits. It must be called with s.mu held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) blockHash(height int64) string {
+	s.nonce++
+	h := sha256.Sum256(fmt.Appendf(nil, "junobroadcasttest/%d/%d", height, s.nonce))
+	return hex.EncodeToString(h[:])
+}
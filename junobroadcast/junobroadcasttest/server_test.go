@@ -0,0 +1,224 @@
+package junobroadcasttest_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junobroadcast"
+	"github.com/Abdullah1738/juno-sdk-go/junobroadcast/junobroadcasttest"
+	"github.com/Abdullah1738/juno-sdk-go/junocashd/junocashdtest"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func rawTx(seed byte, expiry uint32) (string, string) {
+	var a junotx.OrchardAction
+	a.Nullifier[0], a.CMX[0] = seed, seed+1
+	tx := &junotx.Transaction{
+		Version:           junotx.TxVersion5,
+		VersionGroupID:    junotx.TxVersionGroupID5,
+		ConsensusBranchID: types.ConsensusBranchID,
+		ExpiryHeight:      expiry,
+		Orchard: &junotx.OrchardBundle{
+			Actions:      []junotx.OrchardAction{a},
+			Flags:        junotx.OrchardFlagEnableSpends | junotx.OrchardFlagEnableOutputs,
+			ValueBalance: 10_000,
+			Proof:        []byte{1},
+		},
+	}
+	return hex.EncodeToString(tx.Serialize()), tx.TxID()
+}
+
+func newClient(t *testing.T, opts ...junobroadcasttest.Option) (*junobroadcasttest.Server, *junobroadcast.Client) {
+	t.Helper()
+	srv := junobroadcasttest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+	return srv, srv.Client(junobroadcast.WithPollInterval(time.Millisecond))
+}
+
+// waitSubmitted blocks until the server knows n transactions.
+func waitSubmitted(t *testing.T, srv *junobroadcasttest.Server, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(srv.Transactions()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("submit did not arrive")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestServer_SubmitStatusAndMine(t *testing.T) {
+	t.Parallel()
+	srv, cli := newClient(t)
+	ctx := context.Background()
+	raw, txid := rawTx(1, 0)
+
+	resp, err := cli.Submit(ctx, raw, nil)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if resp.TxID != txid || resp.Status != nil {
+		t.Fatalf("resp=%+v", resp)
+	}
+	st, found, err := cli.Status(ctx, txid)
+	if err != nil || !found || !st.InMempool || st.Confirmations != 0 {
+		t.Fatalf("status=%+v found=%v err=%v", st, found, err)
+	}
+
+	srv.Mine(1)
+	st, _, _ = cli.Status(ctx, txid)
+	if st.InMempool || st.Confirmations != 1 || st.BlockHash == "" {
+		t.Fatalf("status=%+v", st)
+	}
+	minedIn := st.BlockHash
+
+	done := make(chan junobroadcast.TxStatus, 1)
+	go func() {
+		st, err := cli.WaitForConfirmations(ctx, txid, 3)
+		if err != nil {
+			t.Errorf("WaitForConfirmations: %v", err)
+		}
+		done <- st
+	}()
+	srv.Mine(2)
+	if st := <-done; st.Confirmations != 3 || st.BlockHash != minedIn {
+		t.Fatalf("status=%+v", st)
+	}
+
+	if err := srv.Reorg(3); err != nil {
+		t.Fatalf("Reorg: %v", err)
+	}
+	if st, _, _ := cli.Status(ctx, txid); !st.InMempool || srv.Height() != 0 {
+		t.Fatalf("status after reorg=%+v height=%d", st, srv.Height())
+	}
+	srv.Mine(1)
+	if st, _, _ := cli.Status(ctx, txid); st.Confirmations != 1 || st.BlockHash == minedIn {
+		t.Fatalf("status after re-mine=%+v", st)
+	}
+
+	if !srv.Evict(txid) {
+		t.Fatalf("Evict: not found")
+	}
+	if _, found, err := cli.Status(ctx, txid); err != nil || found {
+		t.Fatalf("found=%v err=%v, want not found", found, err)
+	}
+}
+
+func TestServer_SubmitWaitConfirmations(t *testing.T) {
+	t.Parallel()
+	srv, cli := newClient(t)
+	ctx := context.Background()
+	raw, txid := rawTx(1, 0)
+
+	two := int64(2)
+	done := make(chan junobroadcast.SubmitResponse, 1)
+	go func() {
+		resp, err := cli.Submit(ctx, raw, &two)
+		if err != nil {
+			t.Errorf("Submit: %v", err)
+		}
+		done <- resp
+	}()
+	waitSubmitted(t, srv, 1)
+	srv.Mine(1)
+	select {
+	case <-done:
+		t.Fatalf("submit returned after one confirmation")
+	case <-time.After(20 * time.Millisecond):
+	}
+	srv.Mine(1)
+	resp := <-done
+	if resp.TxID != txid || resp.Status == nil || resp.Status.Confirmations != 2 {
+		t.Fatalf("resp=%+v", resp)
+	}
+
+	zero := int64(0)
+	raw2, _ := rawTx(2, 0)
+	resp, err := cli.Submit(ctx, raw2, &zero)
+	if err != nil || resp.Status == nil || !resp.Status.InMempool {
+		t.Fatalf("resp=%+v err=%v", resp, err)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	t.Parallel()
+	srv, cli := newClient(t, junobroadcasttest.WithWaitTimeout(50*time.Millisecond))
+	ctx := context.Background()
+	raw, txid := rawTx(1, 0)
+
+	var ae *junobroadcast.APIError
+	srv.FailNext("POST /v1/tx/submit", http.StatusServiceUnavailable, "node_unavailable", "junocashd is syncing")
+	if _, err := cli.Submit(ctx, raw, nil); !errors.As(err, &ae) || ae.Code != "node_unavailable" || ae.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err=%v, want injected node_unavailable", err)
+	}
+	srv.FailNext("GET /v1/tx/{txid}", http.StatusInternalServerError, "internal", "boom")
+	if _, _, err := cli.Status(ctx, txid); !errors.As(err, &ae) || ae.Code != "internal" {
+		t.Fatalf("err=%v, want injected internal", err)
+	}
+	if _, err := cli.Submit(ctx, "zz", nil); !errors.As(err, &ae) || ae.Code != "invalid_request" {
+		t.Fatalf("err=%v, want invalid_request", err)
+	}
+
+	one := int64(1)
+	if _, err := cli.Submit(ctx, raw, &one); !errors.As(err, &ae) || ae.Code != "timeout" {
+		t.Fatalf("err=%v, want timeout", err)
+	}
+
+	raw2, txid2 := rawTx(2, 0)
+	errc := make(chan error, 1)
+	go func() {
+		_, err := cli.Submit(ctx, raw2, &one)
+		errc <- err
+	}()
+	waitSubmitted(t, srv, 2)
+	srv.Evict(txid2)
+	if err := <-errc; !errors.As(err, &ae) || ae.Code != "dropped" {
+		t.Fatalf("err=%v, want dropped", err)
+	}
+}
+
+func TestServer_ForwardsToNode(t *testing.T) {
+	t.Parallel()
+	node := junocashdtest.NewServer()
+	t.Cleanup(node.Close)
+	node.Mine(10)
+	_, cli := newClient(t, junobroadcasttest.WithNode(node.Client()))
+	ctx := context.Background()
+	raw, txid := rawTx(1, 100)
+
+	if _, err := cli.Submit(ctx, raw, nil); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got := node.Mempool(); len(got) != 1 || got[0] != txid {
+		t.Fatalf("node mempool=%v", got)
+	}
+	if st, found, err := cli.Status(ctx, txid); err != nil || !found || !st.InMempool {
+		t.Fatalf("status=%+v found=%v err=%v", st, found, err)
+	}
+
+	hashes := node.Mine(2)
+	st, err := cli.WaitForConfirmations(ctx, txid, 2)
+	if err != nil {
+		t.Fatalf("WaitForConfirmations: %v", err)
+	}
+	if st.BlockHash != hashes[0] || st.InMempool {
+		t.Fatalf("status=%+v", st)
+	}
+	if resp, err := cli.Submit(ctx, raw, nil); err != nil || resp.TxID != txid {
+		t.Fatalf("resubmit resp=%+v err=%v", resp, err)
+	}
+
+	var ae *junobroadcast.APIError
+	expired, _ := rawTx(2, 5)
+	if _, err := cli.Submit(ctx, expired, nil); !errors.As(err, &ae) || ae.Code != "rejected" {
+		t.Fatalf("err=%v, want rejected", err)
+	}
+	if _, found, err := cli.Status(ctx, "00"); err != nil || found {
+		t.Fatalf("found=%v err=%v", found, err)
+	}
+}
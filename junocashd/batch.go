@@ -0,0 +1,154 @@
+package junocashd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const defaultBatchSize = 500
+
+// BatchRequest is a single entry of a JSON-RPC batch. After CallBatch returns,
+// Out holds the decoded result and Err holds the per-entry error (typically an *RPCError).
+type BatchRequest struct {
+	Method string
+	Params any
+	Out    any
+	Err    error
+}
+
+// CallBatch sends all requests as a single JSON-RPC array and matches the responses back by id.
+// The returned error only reports transport-level failures; per-entry failures are stored in Err.
+func (c *Client) CallBatch(ctx context.Context, reqs []BatchRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	if err := c.checkReady(); err != nil {
+		return err
+	}
+
+	byID := make(map[uint64]int, len(reqs))
+	msgs := make([]rpcRequest, len(reqs))
+	for i := range reqs {
+		if strings.TrimSpace(reqs[i].Method) == "" {
+			return fmt.Errorf("junocashd: batch entry %d: method is required", i)
+		}
+		params := reqs[i].Params
+		if params == nil {
+			params = []any{}
+		}
+		id := c.nextID.Add(1)
+		byID[id] = i
+		msgs[i] = rpcRequest{
+			JSONRPC: rpcVersion,
+			ID:      id,
+			Method:  reqs[i].Method,
+			Params:  params,
+		}
+		reqs[i].Err = nil
+	}
+
+	reqBody, err := json.Marshal(msgs)
+	if err != nil {
+		return fmt.Errorf("junocashd: marshal request: %w", err)
+	}
+
+	statusCode, status, body, err := c.post(ctx, reqBody)
+	if err != nil {
+		return err
+	}
+
+	var resps []rpcResponse
+	if err := json.Unmarshal(body, &resps); err != nil {
+		// The node answers with a single object when the batch as a whole is rejected.
+		var single rpcResponse
+		if json.Unmarshal(body, &single) == nil && single.Error != nil {
+			return single.Error
+		}
+		if err := httpStatusError(statusCode, status, body); err != nil {
+			return err
+		}
+		return fmt.Errorf("junocashd: unmarshal response: %w", err)
+	}
+
+	seen := make([]bool, len(reqs))
+	for _, resp := range resps {
+		i, ok := byID[resp.ID]
+		if !ok || seen[i] {
+			continue
+		}
+		seen[i] = true
+		if resp.Error != nil {
+			reqs[i].Err = resp.Error
+			continue
+		}
+		reqs[i].Err = decodeResult(resp.Result, reqs[i].Out)
+	}
+	for i := range reqs {
+		if !seen[i] {
+			reqs[i].Err = errors.New("junocashd: missing response for batch entry")
+		}
+	}
+	return nil
+}
+
+// GetBlockHashes returns the block hashes for heights from..to (inclusive).
+func (c *Client) GetBlockHashes(ctx context.Context, from, to int64) ([]string, error) {
+	if from < 0 || to < from {
+		return nil, fmt.Errorf("junocashd: invalid height range %d..%d", from, to)
+	}
+
+	out := make([]string, to-from+1)
+	for start := from; start <= to; start += defaultBatchSize {
+		end := min(start+defaultBatchSize-1, to)
+		reqs := make([]BatchRequest, 0, end-start+1)
+		for h := start; h <= end; h++ {
+			reqs = append(reqs, BatchRequest{
+				Method: "getblockhash",
+				Params: []any{h},
+				Out:    &out[h-from],
+			})
+		}
+		if err := c.CallBatch(ctx, reqs); err != nil {
+			return nil, err
+		}
+		if err := firstBatchError(reqs); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// GetBlockHeaders returns the headers for the given block hashes, in the same order.
+func (c *Client) GetBlockHeaders(ctx context.Context, hashes []string) ([]BlockHeader, error) {
+	out := make([]BlockHeader, len(hashes))
+	for start := 0; start < len(hashes); start += defaultBatchSize {
+		end := min(start+defaultBatchSize, len(hashes))
+		reqs := make([]BatchRequest, 0, end-start)
+		for i := start; i < end; i++ {
+			reqs = append(reqs, BatchRequest{
+				Method: "getblockheader",
+				Params: []any{hashes[i], true},
+				Out:    &out[i],
+			})
+		}
+		if err := c.CallBatch(ctx, reqs); err != nil {
+			return nil, err
+		}
+		if err := firstBatchError(reqs); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func firstBatchError(reqs []BatchRequest) error {
+	for _, r := range reqs {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package junocashd_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+)
+
+type batchReq struct {
+	ID     uint64 `json:"id"`
+	Method string `json:"method"`
+	Params []any  `json:"params"`
+}
+
+func TestClient_CallBatch_MatchesResponsesByID(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []batchReq
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if len(reqs) != 3 {
+			t.Fatalf("batch size=%d", len(reqs))
+		}
+
+		// Answer in reverse order to make sure the client matches by id.
+		resps := make([]map[string]any, 0, len(reqs))
+		for i := len(reqs) - 1; i >= 0; i-- {
+			req := reqs[i]
+			switch req.Method {
+			case "getblockcount":
+				resps = append(resps, map[string]any{"result": 42, "error": nil, "id": req.ID})
+			case "getbestblockhash":
+				resps = append(resps, map[string]any{"result": "best", "error": nil, "id": req.ID})
+			default:
+				resps = append(resps, map[string]any{
+					"result": nil,
+					"error":  map[string]any{"code": -32601, "message": "Method not found"},
+					"id":     req.ID,
+				})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+
+	var count int64
+	var best string
+	reqs := []junocashd.BatchRequest{
+		{Method: "getblockcount", Out: &count},
+		{Method: "getbestblockhash", Out: &best},
+		{Method: "nope"},
+	}
+	if err := cli.CallBatch(context.Background(), reqs); err != nil {
+		t.Fatalf("CallBatch: %v", err)
+	}
+	if reqs[0].Err != nil || count != 42 {
+		t.Fatalf("count=%d err=%v", count, reqs[0].Err)
+	}
+	if reqs[1].Err != nil || best != "best" {
+		t.Fatalf("best=%q err=%v", best, reqs[1].Err)
+	}
+	var rpcErr *junocashd.RPCError
+	if !errors.As(reqs[2].Err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Fatalf("err=%v", reqs[2].Err)
+	}
+}
+
+func TestClient_CallBatch_MissingResponse(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []batchReq
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"result": 1, "error": nil, "id": reqs[0].ID},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	reqs := []junocashd.BatchRequest{
+		{Method: "getblockcount"},
+		{Method: "getblockcount"},
+	}
+	if err := cli.CallBatch(context.Background(), reqs); err != nil {
+		t.Fatalf("CallBatch: %v", err)
+	}
+	if reqs[0].Err != nil {
+		t.Fatalf("err0=%v", reqs[0].Err)
+	}
+	if reqs[1].Err == nil {
+		t.Fatalf("expected missing response error")
+	}
+}
+
+func TestClient_CallBatch_WholeBatchRejected(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"result":null,"error":{"code":-32700,"message":"Parse error"},"id":null}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	err := cli.CallBatch(context.Background(), []junocashd.BatchRequest{{Method: "getblockcount"}})
+	var rpcErr *junocashd.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32700 {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestClient_GetBlockHashesAndHeaders(t *testing.T) {
+	t.Parallel()
+
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		var reqs []batchReq
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		resps := make([]map[string]any, 0, len(reqs))
+		for _, req := range reqs {
+			switch req.Method {
+			case "getblockhash":
+				resps = append(resps, map[string]any{
+					"result": fmt.Sprintf("hash%d", int64(req.Params[0].(float64))),
+					"error":  nil,
+					"id":     req.ID,
+				})
+			case "getblockheader":
+				if req.Params[1] != true {
+					t.Fatalf("param1=%v", req.Params[1])
+				}
+				var height int64
+				_, _ = fmt.Sscanf(req.Params[0].(string), "hash%d", &height)
+				resps = append(resps, map[string]any{
+					"result": map[string]any{"hash": req.Params[0], "height": height, "time": 0},
+					"error":  nil,
+					"id":     req.ID,
+				})
+			default:
+				t.Fatalf("method=%q", req.Method)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	ctx := context.Background()
+
+	hashes, err := cli.GetBlockHashes(ctx, 10, 12)
+	if err != nil {
+		t.Fatalf("GetBlockHashes: %v", err)
+	}
+	if len(hashes) != 3 || hashes[0] != "hash10" || hashes[2] != "hash12" {
+		t.Fatalf("hashes=%v", hashes)
+	}
+
+	headers, err := cli.GetBlockHeaders(ctx, hashes)
+	if err != nil {
+		t.Fatalf("GetBlockHeaders: %v", err)
+	}
+	if len(headers) != 3 || headers[1].Hash != "hash11" || headers[1].Height != 11 {
+		t.Fatalf("headers=%+v", headers)
+	}
+	if posts != 2 {
+		t.Fatalf("posts=%d want 2", posts)
+	}
+
+	if _, err := cli.GetBlockHashes(ctx, 5, 4); err == nil {
+		t.Fatalf("expected invalid range error")
+	}
+}
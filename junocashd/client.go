@@ -82,11 +82,52 @@ func (c *Client) Call(ctx context.Context, method string, params any, out any) e
 	if strings.TrimSpace(method) == "" {
 		return errors.New("junocashd: method is required")
 	}
-	if c.endpoint == "" {
-		return errors.New("junocashd: endpoint is required")
+	if err := c.checkReady(); err != nil {
+		return err
 	}
-	if c.http == nil {
-		return errors.New("junocashd: http client is nil")
+
+	id := c.nextID.Add(1)
+	if params == nil {
+		params = []any{}
+	}
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: rpcVersion,
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("junocashd: marshal request: %w", err)
+	}
+
+	statusCode, status, body, err := c.post(ctx, reqBody)
+	if err != nil {
+		return err
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(body, &rpcResp); err == nil && rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if err := httpStatusError(statusCode, status, body); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("junocashd: unmarshal response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	return decodeResult(rpcResp.Result, out)
+}
+
+// callStream behaves like Call but decodes the response directly from the body,
+// so results are not subject to the response size limit used by Call.
+func (c *Client) callStream(ctx context.Context, method string, params any, out any) error {
+	if err := c.checkReady(); err != nil {
+		return err
 	}
 
 	id := c.nextID.Add(1)
@@ -103,9 +144,66 @@ func (c *Client) Call(ctx context.Context, method string, params any, out any) e
 		return fmt.Errorf("junocashd: marshal request: %w", err)
 	}
 
+	resp, err := c.do(ctx, reqBody)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, err := readLimited(resp.Body)
+		if err != nil {
+			return err
+		}
+		var rpcResp rpcResponse
+		if err := json.Unmarshal(body, &rpcResp); err == nil && rpcResp.Error != nil {
+			return rpcResp.Error
+		}
+		return httpStatusError(resp.StatusCode, resp.Status, body)
+	}
+
+	var streamResp struct {
+		Result any       `json:"result"`
+		Error  *RPCError `json:"error"`
+	}
+	streamResp.Result = out
+	if err := json.NewDecoder(resp.Body).Decode(&streamResp); err != nil {
+		return fmt.Errorf("junocashd: unmarshal response: %w", err)
+	}
+	if streamResp.Error != nil {
+		return streamResp.Error
+	}
+	return nil
+}
+
+func (c *Client) checkReady() error {
+	if c.endpoint == "" {
+		return errors.New("junocashd: endpoint is required")
+	}
+	if c.http == nil {
+		return errors.New("junocashd: http client is nil")
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, reqBody []byte) (int, string, []byte, error) {
+	resp, err := c.do(ctx, reqBody)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := readLimited(resp.Body)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	return resp.StatusCode, resp.Status, body, nil
+}
+
+func (c *Client) do(ctx context.Context, reqBody []byte) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(reqBody))
 	if err != nil {
-		return fmt.Errorf("junocashd: new request: %w", err)
+		return nil, fmt.Errorf("junocashd: new request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
@@ -115,42 +213,39 @@ func (c *Client) Call(ctx context.Context, method string, params any, out any) e
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("junocashd: request: %w", err)
+		return nil, fmt.Errorf("junocashd: request: %w", err)
 	}
-	defer resp.Body.Close()
+	return resp, nil
+}
 
+func readLimited(r io.Reader) ([]byte, error) {
 	const maxBodyBytes = 8 << 20 // 8 MiB
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	body, err := io.ReadAll(io.LimitReader(r, maxBodyBytes))
 	if err != nil {
-		return fmt.Errorf("junocashd: read response: %w", err)
+		return nil, fmt.Errorf("junocashd: read response: %w", err)
 	}
+	return body, nil
+}
 
-	var rpcResp rpcResponse
-	if err := json.Unmarshal(body, &rpcResp); err == nil && rpcResp.Error != nil {
-		return rpcResp.Error
+func httpStatusError(statusCode int, status string, body []byte) error {
+	if statusCode >= 200 && statusCode <= 299 {
+		return nil
 	}
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		msg := strings.TrimSpace(string(body))
-		if msg == "" {
-			msg = resp.Status
-		}
-		return fmt.Errorf("junocashd: http %d: %s", resp.StatusCode, msg)
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		msg = status
 	}
+	return fmt.Errorf("junocashd: http %d: %s", statusCode, msg)
+}
 
-	if err := json.Unmarshal(body, &rpcResp); err != nil {
-		return fmt.Errorf("junocashd: unmarshal response: %w", err)
-	}
-	if rpcResp.Error != nil {
-		return rpcResp.Error
-	}
+func decodeResult(result json.RawMessage, out any) error {
 	if out == nil {
 		return nil
 	}
-	if len(rpcResp.Result) == 0 || bytes.Equal(rpcResp.Result, []byte("null")) {
+	if len(result) == 0 || bytes.Equal(result, []byte("null")) {
 		return nil
 	}
-	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+	if err := json.Unmarshal(result, out); err != nil {
 		return fmt.Errorf("junocashd: unmarshal result: %w", err)
 	}
 	return nil
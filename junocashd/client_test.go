@@ -1,9 +1,12 @@
 package junocashd_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -177,3 +180,291 @@ func TestClient_GetBlockHeader_Params(t *testing.T) {
 		t.Fatalf("GetBlockHeader: %v", err)
 	}
 }
+
+func TestClient_Mempool(t *testing.T) {
+	t.Parallel()
+
+	type req struct {
+		Method string `json:"method"`
+		Params []any  `json:"params"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got req
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+
+		var result any
+		switch got.Method {
+		case "getrawmempool":
+			if len(got.Params) != 1 {
+				t.Fatalf("params=%v", got.Params)
+			}
+			if got.Params[0] == false {
+				result = []string{"aa", "bb"}
+				break
+			}
+			result = map[string]any{
+				"aa": map[string]any{
+					"size":    1234,
+					"fee":     0.0001,
+					"time":    1700000000,
+					"height":  99,
+					"depends": []string{},
+				},
+				"bb": map[string]any{
+					"size":    2000,
+					"fee":     0.0002,
+					"time":    1700000001,
+					"height":  99,
+					"depends": []string{"aa"},
+				},
+			}
+		case "getmempoolinfo":
+			result = map[string]any{"size": 2, "bytes": 3234, "usage": 9000}
+		case "getmempoolentry":
+			if len(got.Params) != 1 || got.Params[0] != "bb" {
+				t.Fatalf("params=%v", got.Params)
+			}
+			result = map[string]any{"size": 2000, "fee": 0.0002, "time": 1700000001, "height": 99, "depends": []string{"aa"}}
+		default:
+			t.Fatalf("method=%q", got.Method)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "error": nil, "id": 1})
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	ctx := context.Background()
+
+	txids, err := cli.GetRawMempool(ctx)
+	if err != nil {
+		t.Fatalf("GetRawMempool: %v", err)
+	}
+	if len(txids) != 2 {
+		t.Fatalf("txids=%v", txids)
+	}
+
+	entries, err := cli.GetRawMempoolVerbose(ctx)
+	if err != nil {
+		t.Fatalf("GetRawMempoolVerbose: %v", err)
+	}
+	if len(entries) != 2 || entries["bb"].Size != 2000 || len(entries["bb"].Depends) != 1 {
+		t.Fatalf("entries=%+v", entries)
+	}
+
+	info, err := cli.GetMempoolInfo(ctx)
+	if err != nil {
+		t.Fatalf("GetMempoolInfo: %v", err)
+	}
+	if info.Size != 2 || info.Bytes != 3234 {
+		t.Fatalf("info=%+v", info)
+	}
+
+	entry, err := cli.GetMempoolEntry(ctx, "bb")
+	if err != nil {
+		t.Fatalf("GetMempoolEntry: %v", err)
+	}
+	if entry.Height != 99 || entry.Depends[0] != "aa" {
+		t.Fatalf("entry=%+v", entry)
+	}
+}
+
+func TestClient_GetRawTransactionVerbose(t *testing.T) {
+	t.Parallel()
+
+	type req struct {
+		Method string `json:"method"`
+		Params []any  `json:"params"`
+	}
+
+	const txJSON = `{
+		"txid": "aa",
+		"authdigest": "bb",
+		"overwintered": true,
+		"version": 5,
+		"versiongroupid": "26a7270a",
+		"locktime": 0,
+		"expiryheight": 140,
+		"vin": [],
+		"vout": [],
+		"orchard": {
+			"actions": [
+				{"cv": "01", "nullifier": "n0", "rk": "02", "cmx": "c0", "ephemeralKey": "03", "encCiphertext": "04", "outCiphertext": "05", "spendAuthSig": "06"},
+				{"cv": "11", "nullifier": "n1", "rk": "12", "cmx": "c1", "ephemeralKey": "13", "encCiphertext": "14", "outCiphertext": "15", "spendAuthSig": "16"}
+			],
+			"valueBalance": 0.0001,
+			"valueBalanceZat": 10000,
+			"flags": {"enableSpends": true, "enableOutputs": true},
+			"anchor": "dd",
+			"proof": "ee",
+			"bindingSig": "ff"
+		},
+		"blockhash": "block",
+		"height": 120,
+		"confirmations": 3
+	}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got req
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		switch got.Method {
+		case "getrawtransaction":
+			if len(got.Params) != 2 || got.Params[0] != "aa" || got.Params[1] != float64(1) {
+				t.Fatalf("params=%v", got.Params)
+			}
+		case "decoderawtransaction":
+			if len(got.Params) != 1 || got.Params[0] != "0500" {
+				t.Fatalf("params=%v", got.Params)
+			}
+		default:
+			t.Fatalf("method=%q", got.Method)
+		}
+		_, _ = w.Write([]byte(`{"result":` + txJSON + `,"error":null,"id":1}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+
+	tx, err := cli.GetRawTransactionVerbose(context.Background(), "aa")
+	if err != nil {
+		t.Fatalf("GetRawTransactionVerbose: %v", err)
+	}
+	if tx.Version != 5 || tx.VersionGroupID != "26a7270a" || tx.ExpiryHeight != 140 {
+		t.Fatalf("tx=%+v", tx)
+	}
+	if tx.BlockHash != "block" || tx.Height != 120 || tx.Confirmations != 3 {
+		t.Fatalf("tx block fields=%+v", tx)
+	}
+	if tx.Orchard.ActionCount() != 2 {
+		t.Fatalf("actions=%d", tx.Orchard.ActionCount())
+	}
+	if nfs := tx.Orchard.Nullifiers(); nfs[0] != "n0" || nfs[1] != "n1" {
+		t.Fatalf("nullifiers=%v", nfs)
+	}
+	if cmxs := tx.Orchard.CMXs(); cmxs[1] != "c1" {
+		t.Fatalf("cmxs=%v", cmxs)
+	}
+	if tx.Orchard.ValueBalanceZat != 10000 || !tx.Orchard.Flags.EnableSpends {
+		t.Fatalf("orchard=%+v", tx.Orchard)
+	}
+
+	decoded, err := cli.DecodeRawTransaction(context.Background(), "0500")
+	if err != nil {
+		t.Fatalf("DecodeRawTransaction: %v", err)
+	}
+	if decoded.TxID != "aa" {
+		t.Fatalf("txid=%q", decoded.TxID)
+	}
+
+	var none *junocashd.OrchardBundle
+	if none.ActionCount() != 0 || none.Nullifiers() != nil {
+		t.Fatalf("nil bundle should be empty")
+	}
+}
+
+func TestClient_GetBlockWithTxs_Params(t *testing.T) {
+	t.Parallel()
+
+	type req struct {
+		Method string `json:"method"`
+		Params []any  `json:"params"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got req
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if got.Method != "getblock" {
+			t.Fatalf("method=%q", got.Method)
+		}
+		if len(got.Params) != 2 || got.Params[0] != "hash" || got.Params[1] != float64(2) {
+			t.Fatalf("params=%v", got.Params)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"result": map[string]any{
+				"hash":   "hash",
+				"height": 7,
+				"time":   0,
+				"tx": []map[string]any{
+					{"txid": "aa", "version": 5, "locktime": 0, "vin": []any{}, "vout": []any{}},
+				},
+			},
+			"error": nil,
+			"id":    1,
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	block, err := cli.GetBlockWithTxs(context.Background(), "hash")
+	if err != nil {
+		t.Fatalf("GetBlockWithTxs: %v", err)
+	}
+	if block.Height != 7 || len(block.Tx) != 1 || block.Tx[0].TxID != "aa" || block.Tx[0].Version != 5 {
+		t.Fatalf("block=%+v", block)
+	}
+}
+
+func TestClient_GetBlockRaw_LargerThanCallLimit(t *testing.T) {
+	t.Parallel()
+
+	const rawSize = 5 << 20 // hex-encoded this exceeds the 8 MiB limit of Call
+	raw := make([]byte, rawSize)
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+
+	type req struct {
+		Method string `json:"method"`
+		Params []any  `json:"params"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got req
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if got.Method != "getblock" || len(got.Params) != 2 || got.Params[1] != float64(0) {
+			t.Fatalf("method=%q params=%v", got.Method, got.Params)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"result": hex.EncodeToString(raw),
+			"error":  nil,
+			"id":     1,
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	got, err := cli.GetBlockRaw(context.Background(), "hash")
+	if err != nil {
+		t.Fatalf("GetBlockRaw: %v", err)
+	}
+	if !bytes.Equal(got, raw) {
+		t.Fatalf("raw block mismatch (len=%d)", len(got))
+	}
+}
+
+func TestClient_GetBlockRaw_RPCError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"result":null,"error":{"code":-5,"message":"Block not found"},"id":1}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := junocashd.New(srv.URL, "", "")
+	_, err := cli.GetBlockRaw(context.Background(), "hash")
+	var rpcErr *junocashd.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -5 {
+		t.Fatalf("err=%v", err)
+	}
+}
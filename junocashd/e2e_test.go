@@ -11,8 +11,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Abdullah1738/juno-sdk-go/internal/testutil"
 	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
 )
 
 func TestJunocashdAndCLI_E2E(t *testing.T) {
@@ -25,12 +25,12 @@ func TestJunocashdAndCLI_E2E(t *testing.T) {
 		}
 	}
 
-	r, err := testutil.StartJunocashd(ctx, testutil.JunocashdConfig{})
+	r, err := junoregtest.Start(ctx, junoregtest.Config{})
 	if err != nil {
-		if errors.Is(err, testutil.ErrJunocashdNotFound) {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
 			t.Skip("junocashd not found in PATH")
 		}
-		t.Fatalf("StartJunocashd: %v", err)
+		t.Fatalf("Start: %v", err)
 	}
 	defer func() { _ = r.Stop(context.Background()) }()
 
@@ -0,0 +1,319 @@
+package junocashd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+const (
+	defaultChainPollInterval  = 2 * time.Second
+	defaultChainMaxReorgDepth = 100
+	chainFetchChunk           = 100
+)
+
+// ErrReorgTooDeep is returned by ChainFollower.Run when the fork point of a reorg is more than
+// the maximum reorg depth below the followed tip.
+var ErrReorgTooDeep = errors.New("junocashd: reorg deeper than the follower window")
+
+// ChainEvent is one item of the ChainFollower stream. Exactly one of Block and Reorg is set.
+type ChainEvent struct {
+	// Block is a block connected on top of the followed tip.
+	Block *BlockHeader
+	// Reorg reports that the blocks above Reorg.To, up to the previous tip Reorg.From, left the
+	// best chain. Reorg.To is the fork point; the blocks of the new branch follow as Block events.
+	Reorg *types.ReorgEvent
+}
+
+// Cursor returns the followed tip after e has been applied, which is what a consumer should
+// persist once it has handled e.
+func (e ChainEvent) Cursor() types.ChainCursor {
+	switch {
+	case e.Reorg != nil:
+		return e.Reorg.To
+	case e.Block != nil:
+		return types.ChainCursor{Height: e.Block.Height, Hash: e.Block.Hash}
+	default:
+		return types.ChainCursor{}
+	}
+}
+
+// ChainHandler processes one chain event. Returning an error stops the follower without
+// advancing its tip past the event.
+type ChainHandler func(ctx context.Context, e ChainEvent) error
+
+// ChainFollower tracks the best chain of a junocashd node and reports connected blocks and
+// reorgs, in order, to a handler.
+//
+// The follower keeps a bounded window of recent block cursors. Each block must link to the
+// followed tip through PreviousBlockHash; when it does not, the follower walks back from the tip
+// (through the window, then through the node's headers of the stale branch) to the highest block
+// still on the best chain and emits a ReorgEvent to it. Started from a persisted cursor, it
+// detects reorgs that happened while it was stopped the same way.
+type ChainFollower struct {
+	client  *Client
+	handler ChainHandler
+	start   *types.ChainCursor
+
+	pollInterval  time.Duration
+	maxReorgDepth int
+	onError       func(err error)
+	sleep         func(ctx context.Context, d time.Duration) error
+
+	mu sync.Mutex
+	// window holds cursors of consecutive heights ending at the followed tip.
+	window []types.ChainCursor
+}
+
+type ChainFollowerOption func(*ChainFollower)
+
+// WithChainPollInterval sets how often the node is polled for a new best block.
+func WithChainPollInterval(d time.Duration) ChainFollowerOption {
+	return func(f *ChainFollower) {
+		if d > 0 {
+			f.pollInterval = d
+		}
+	}
+}
+
+// WithChainMaxReorgDepth sets the deepest reorg the follower resolves, which is also the number
+// of recent cursors it keeps.
+func WithChainMaxReorgDepth(n int) ChainFollowerOption {
+	return func(f *ChainFollower) {
+		if n > 0 {
+			f.maxReorgDepth = n
+		}
+	}
+}
+
+// WithChainErrorHandler registers a callback for transient RPC errors. They are retried at the
+// next poll; without a callback they are dropped silently.
+func WithChainErrorHandler(fn func(err error)) ChainFollowerOption {
+	return func(f *ChainFollower) {
+		f.onError = fn
+	}
+}
+
+// NewChainFollower returns a follower that resumes from start, typically the cursor of the last
+// event handled before a restart. With a nil start it begins at the node's best block when Run
+// is called, without emitting it.
+func NewChainFollower(c *Client, start *types.ChainCursor, handler ChainHandler, opts ...ChainFollowerOption) (*ChainFollower, error) {
+	if c == nil {
+		return nil, errors.New("junocashd: follower client required")
+	}
+	if handler == nil {
+		return nil, errors.New("junocashd: follower handler required")
+	}
+	if start != nil && (start.Height < 0 || start.Hash == "") {
+		return nil, fmt.Errorf("junocashd: invalid start cursor %d/%q", start.Height, start.Hash)
+	}
+
+	f := &ChainFollower{
+		client:        c,
+		handler:       handler,
+		start:         start,
+		pollInterval:  defaultChainPollInterval,
+		maxReorgDepth: defaultChainMaxReorgDepth,
+		sleep:         sleepContext,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(f)
+		}
+	}
+	if start != nil {
+		f.window = []types.ChainCursor{*start}
+	}
+	return f, nil
+}
+
+// Tip returns the followed tip, or false before the follower has a starting point.
+func (f *ChainFollower) Tip() (types.ChainCursor, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.window) == 0 {
+		return types.ChainCursor{}, false
+	}
+	return f.window[len(f.window)-1], true
+}
+
+// Run follows the chain until ctx is done, the handler fails or a reorg exceeds the maximum
+// depth (ErrReorgTooDeep). It returns ctx.Err() on cancellation.
+func (f *ChainFollower) Run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var fe *fetchError
+			if !errors.As(err, &fe) {
+				return err
+			}
+			if f.onError != nil {
+				f.onError(fe.err)
+			}
+		}
+		if err := f.sleep(ctx, f.pollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+type fetchError struct{ err error }
+
+func (e *fetchError) Error() string { return e.err.Error() }
+
+// poll brings the followed tip up to the node's best block.
+func (f *ChainFollower) poll(ctx context.Context) error {
+	bestHash, err := f.client.GetBestBlockHash(ctx)
+	if err != nil {
+		return &fetchError{err: err}
+	}
+	tip, ok := f.Tip()
+	if ok && bestHash == tip.Hash {
+		return nil
+	}
+	best, err := f.client.GetBlockHeader(ctx, bestHash)
+	if err != nil {
+		return &fetchError{err: err}
+	}
+	if !ok {
+		f.setWindow([]types.ChainCursor{{Height: best.Height, Hash: best.Hash}})
+		return nil
+	}
+	if best.Height <= tip.Height {
+		// The best chain is no longer than ours but ends elsewhere: our tip is stale.
+		return f.reorg(ctx, best.Height)
+	}
+
+next:
+	for tip.Height < best.Height {
+		from := tip.Height + 1
+		to := min(from+chainFetchChunk-1, best.Height)
+		hashes, err := f.client.GetBlockHashes(ctx, from, to)
+		if err != nil {
+			return &fetchError{err: err}
+		}
+		headers, err := f.client.GetBlockHeaders(ctx, hashes)
+		if err != nil {
+			return &fetchError{err: err}
+		}
+		for i := range headers {
+			h := headers[i]
+			if h.Height != tip.Height+1 {
+				return &fetchError{err: fmt.Errorf("junocashd: header %s has height %d, want %d", h.Hash, h.Height, tip.Height+1)}
+			}
+			if h.PreviousBlockHash != tip.Hash {
+				if err := f.reorg(ctx, best.Height); err != nil {
+					return err
+				}
+				tip, _ = f.Tip()
+				continue next
+			}
+			if err := f.handler(ctx, ChainEvent{Block: &h}); err != nil {
+				return fmt.Errorf("junocashd: handle block %d %s: %w", h.Height, h.Hash, err)
+			}
+			tip = types.ChainCursor{Height: h.Height, Hash: h.Hash}
+			f.push(tip)
+		}
+	}
+	return nil
+}
+
+// reorg finds the fork point below the followed tip, reports it and rewinds the tip to it.
+func (f *ChainFollower) reorg(ctx context.Context, bestHeight int64) error {
+	from, _ := f.Tip()
+	fork, err := f.findFork(ctx, bestHeight)
+	if err != nil {
+		return err
+	}
+	ev := types.ReorgEvent{From: from, To: fork}
+	if err := f.handler(ctx, ChainEvent{Reorg: &ev}); err != nil {
+		return fmt.Errorf("junocashd: handle reorg %d %s -> %d %s: %w", from.Height, from.Hash, fork.Height, fork.Hash, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keep := 0
+	for i, c := range f.window {
+		if c == fork {
+			keep = i + 1
+		}
+	}
+	if keep == 0 {
+		f.window = []types.ChainCursor{fork}
+	} else {
+		f.window = f.window[:keep]
+	}
+	return nil
+}
+
+// findFork walks back from the followed tip to the highest block that is still on the best
+// chain.
+func (f *ChainFollower) findFork(ctx context.Context, bestHeight int64) (types.ChainCursor, error) {
+	f.mu.Lock()
+	window := append([]types.ChainCursor(nil), f.window...)
+	f.mu.Unlock()
+
+	i := len(window) - 1
+	cur := window[i]
+	for depth := 0; depth <= f.maxReorgDepth; depth++ {
+		if cur.Height <= bestHeight {
+			hash, err := f.client.GetBlockHash(ctx, cur.Height)
+			if err != nil {
+				return types.ChainCursor{}, &fetchError{err: err}
+			}
+			if hash == cur.Hash {
+				return cur, nil
+			}
+		}
+		if cur.Height == 0 {
+			break
+		}
+		if i > 0 {
+			i--
+			cur = window[i]
+			continue
+		}
+		h, err := f.client.GetBlockHeader(ctx, cur.Hash)
+		if err != nil {
+			return types.ChainCursor{}, &fetchError{err: err}
+		}
+		cur = types.ChainCursor{Height: cur.Height - 1, Hash: h.PreviousBlockHash}
+	}
+	tip := window[len(window)-1]
+	return types.ChainCursor{}, fmt.Errorf("%w: no common ancestor within %d blocks of %d %s", ErrReorgTooDeep, f.maxReorgDepth, tip.Height, tip.Hash)
+}
+
+func (f *ChainFollower) push(c types.ChainCursor) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.window = append(f.window, c)
+	if n := len(f.window) - (f.maxReorgDepth + 1); n > 0 {
+		f.window = append(f.window[:0], f.window[n:]...)
+	}
+}
+
+func (f *ChainFollower) setWindow(w []types.ChainCursor) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.window = w
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
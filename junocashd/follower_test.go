@@ -0,0 +1,281 @@
+package junocashd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+// chainNode serves getbestblockhash, getblockhash and getblockheader (single and batched)
+// over a mutable set of branches. Block hashes are "<branch>-<height>".
+type chainNode struct {
+	mu      sync.Mutex
+	best    []string
+	headers map[string]junocashd.BlockHeader
+}
+
+func newChainNode(t *testing.T, height int) (*chainNode, *junocashd.Client) {
+	t.Helper()
+	n := &chainNode{headers: map[string]junocashd.BlockHeader{}}
+	n.mine("a", height+1)
+	srv := httptest.NewServer(n)
+	t.Cleanup(srv.Close)
+	return n, junocashd.New(srv.URL, "", "")
+}
+
+// mine appends count blocks of branch to the best chain.
+func (n *chainNode) mine(branch string, count int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for range count {
+		h := junocashd.BlockHeader{Height: int64(len(n.best)), Hash: fmt.Sprintf("%s-%d", branch, len(n.best))}
+		if len(n.best) > 0 {
+			h.PreviousBlockHash = n.best[len(n.best)-1]
+		}
+		n.headers[h.Hash] = h
+		n.best = append(n.best, h.Hash)
+	}
+}
+
+// fork rewinds the best chain to height and mines count blocks of branch on top.
+func (n *chainNode) fork(height int64, branch string, count int) {
+	n.mu.Lock()
+	n.best = n.best[:height+1]
+	n.mu.Unlock()
+	n.mine(branch, count)
+}
+
+type rpcReq struct {
+	ID     uint64 `json:"id"`
+	Method string `json:"method"`
+	Params []any  `json:"params"`
+}
+
+func (n *chainNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	_, _ = body.ReadFrom(r.Body)
+	if bytes.HasPrefix(bytes.TrimSpace(body.Bytes()), []byte("[")) {
+		var reqs []rpcReq
+		_ = json.Unmarshal(body.Bytes(), &reqs)
+		resps := make([]map[string]any, len(reqs))
+		for i, req := range reqs {
+			resps[i] = n.answer(req)
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+		return
+	}
+	var req rpcReq
+	_ = json.Unmarshal(body.Bytes(), &req)
+	_ = json.NewEncoder(w).Encode(n.answer(req))
+}
+
+func (n *chainNode) answer(req rpcReq) map[string]any {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	fail := func(code int, msg string) map[string]any {
+		return map[string]any{"result": nil, "error": map[string]any{"code": code, "message": msg}, "id": req.ID}
+	}
+	var result any
+	switch req.Method {
+	case "getbestblockhash":
+		result = n.best[len(n.best)-1]
+	case "getblockhash":
+		h := int(req.Params[0].(float64))
+		if h < 0 || h >= len(n.best) {
+			return fail(-8, "Block height out of range")
+		}
+		result = n.best[h]
+	case "getblockheader":
+		h, ok := n.headers[req.Params[0].(string)]
+		if !ok {
+			return fail(-5, "Block not found")
+		}
+		result = h
+	default:
+		return fail(-32601, "Method not found")
+	}
+	return map[string]any{"result": result, "error": nil, "id": req.ID}
+}
+
+// follow runs a follower until it has delivered want events and returns them. onEvent, if set,
+// is called from the handler with the number of events delivered so far.
+func follow(t *testing.T, cli *junocashd.Client, start *types.ChainCursor, want int, onEvent func(n int)) []junocashd.ChainEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		events []junocashd.ChainEvent
+	)
+	f, err := junocashd.NewChainFollower(cli, start, func(_ context.Context, e junocashd.ChainEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+		if onEvent != nil {
+			onEvent(len(events))
+		}
+		if len(events) == want {
+			cancel()
+		}
+		return nil
+	}, junocashd.WithChainPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewChainFollower: %v", err)
+	}
+
+	if err := f.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v (events=%d)", err, len(events))
+	}
+	return events
+}
+
+func blockHashes(events []junocashd.ChainEvent) []string {
+	var out []string
+	for _, e := range events {
+		if e.Block != nil {
+			out = append(out, e.Block.Hash)
+		} else {
+			out = append(out, fmt.Sprintf("reorg %d:%s->%d:%s", e.Reorg.From.Height, e.Reorg.From.Hash, e.Reorg.To.Height, e.Reorg.To.Hash))
+		}
+	}
+	return out
+}
+
+func TestChainFollower_BlocksAndReorg(t *testing.T) {
+	t.Parallel()
+
+	node, cli := newChainNode(t, 5)
+	node.mine("a", 2)
+	events := follow(t, cli, &types.ChainCursor{Height: 5, Hash: "a-5"}, 6, func(n int) {
+		if n == 2 {
+			node.fork(5, "b", 3)
+		}
+	})
+
+	got := fmt.Sprint(blockHashes(events))
+	want := fmt.Sprint([]string{"a-6", "a-7", "reorg 7:a-7->5:a-5", "b-6", "b-7", "b-8"})
+	if got != want {
+		t.Fatalf("events=%s\nwant   %s", got, want)
+	}
+	if c := events[2].Cursor(); c != (types.ChainCursor{Height: 5, Hash: "a-5"}) {
+		t.Fatalf("reorg cursor=%+v", c)
+	}
+	if c := events[5].Cursor(); c != (types.ChainCursor{Height: 8, Hash: "b-8"}) {
+		t.Fatalf("block cursor=%+v", c)
+	}
+}
+
+func TestChainFollower_RestartFromPersistedCursor(t *testing.T) {
+	t.Parallel()
+
+	node, cli := newChainNode(t, 10)
+
+	// Behind the tip on the best chain: catch up without a reorg.
+	events := follow(t, cli, &types.ChainCursor{Height: 7, Hash: "a-7"}, 3, nil)
+	if got := fmt.Sprint(blockHashes(events)); got != "[a-8 a-9 a-10]" {
+		t.Fatalf("events=%s", got)
+	}
+
+	// The persisted tip was reorged away while the follower was stopped, onto a longer branch.
+	node.fork(4, "b", 8)
+	events = follow(t, cli, &types.ChainCursor{Height: 10, Hash: "a-10"}, 9, nil)
+	want := []string{"reorg 10:a-10->4:a-4", "b-5", "b-6", "b-7", "b-8", "b-9", "b-10", "b-11", "b-12"}
+	if got := fmt.Sprint(blockHashes(events)); got != fmt.Sprint(want) {
+		t.Fatalf("events=%s", got)
+	}
+
+	// A shorter best chain also orphans the persisted tip.
+	node.fork(2, "c", 1)
+	events = follow(t, cli, &types.ChainCursor{Height: 12, Hash: "b-12"}, 2, nil)
+	if got := fmt.Sprint(blockHashes(events)); got != "[reorg 12:b-12->2:a-2 c-3]" {
+		t.Fatalf("events=%s", got)
+	}
+}
+
+func TestChainFollower_StartsAtBestBlock(t *testing.T) {
+	t.Parallel()
+
+	node, cli := newChainNode(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	blocks := make(chan string, 4)
+	f, err := junocashd.NewChainFollower(cli, nil, func(_ context.Context, e junocashd.ChainEvent) error {
+		blocks <- e.Block.Hash
+		return nil
+	}, junocashd.WithChainPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewChainFollower: %v", err)
+	}
+	if _, ok := f.Tip(); ok {
+		t.Fatalf("tip set before Run")
+	}
+	go func() { _ = f.Run(ctx) }()
+
+	for {
+		if tip, ok := f.Tip(); ok {
+			if tip.Hash != "a-3" {
+				t.Fatalf("tip=%+v", tip)
+			}
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	node.mine("a", 1)
+	select {
+	case h := <-blocks:
+		if h != "a-4" {
+			t.Fatalf("block=%s", h)
+		}
+	case <-ctx.Done():
+		t.Fatalf("no block delivered")
+	}
+}
+
+func TestChainFollower_Errors(t *testing.T) {
+	t.Parallel()
+
+	node, cli := newChainNode(t, 10)
+	node.fork(3, "b", 10)
+
+	f, err := junocashd.NewChainFollower(cli, &types.ChainCursor{Height: 10, Hash: "a-10"}, func(context.Context, junocashd.ChainEvent) error {
+		return nil
+	}, junocashd.WithChainPollInterval(time.Millisecond), junocashd.WithChainMaxReorgDepth(4))
+	if err != nil {
+		t.Fatalf("NewChainFollower: %v", err)
+	}
+	if err := f.Run(context.Background()); !errors.Is(err, junocashd.ErrReorgTooDeep) {
+		t.Fatalf("err=%v, want ErrReorgTooDeep", err)
+	}
+
+	boom := errors.New("boom")
+	f, err = junocashd.NewChainFollower(cli, &types.ChainCursor{Height: 12, Hash: "b-12"}, func(_ context.Context, e junocashd.ChainEvent) error {
+		if e.Block.Height == 13 {
+			return boom
+		}
+		return nil
+	}, junocashd.WithChainPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewChainFollower: %v", err)
+	}
+	if err := f.Run(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("err=%v, want handler error", err)
+	}
+	if tip, _ := f.Tip(); tip != (types.ChainCursor{Height: 12, Hash: "b-12"}) {
+		t.Fatalf("tip=%+v, want unchanged", tip)
+	}
+
+	if _, err := junocashd.NewChainFollower(cli, nil, nil); err == nil {
+		t.Fatalf("expected handler error")
+	}
+}
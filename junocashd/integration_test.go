@@ -8,20 +8,20 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Abdullah1738/juno-sdk-go/internal/testutil"
 	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
 )
 
 func TestClient_GetBlockchainInfo_Integration(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
 
-	r, err := testutil.StartJunocashd(ctx, testutil.JunocashdConfig{})
+	r, err := junoregtest.Start(ctx, junoregtest.Config{})
 	if err != nil {
-		if errors.Is(err, testutil.ErrJunocashdNotFound) {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
 			t.Skip("junocashd not found in PATH")
 		}
-		t.Fatalf("StartJunocashd: %v", err)
+		t.Fatalf("Start: %v", err)
 	}
 	defer func() { _ = r.Stop(context.Background()) }()
 
@@ -40,12 +40,12 @@ func TestClient_GetBlockVerbose_Integration(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
 
-	r, err := testutil.StartJunocashd(ctx, testutil.JunocashdConfig{})
+	r, err := junoregtest.Start(ctx, junoregtest.Config{})
 	if err != nil {
-		if errors.Is(err, testutil.ErrJunocashdNotFound) {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
 			t.Skip("junocashd not found in PATH")
 		}
-		t.Fatalf("StartJunocashd: %v", err)
+		t.Fatalf("Start: %v", err)
 	}
 	defer func() { _ = r.Stop(context.Background()) }()
 
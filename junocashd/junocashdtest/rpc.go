@@ -0,0 +1,515 @@
+package junocashdtest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+// RPC error codes used by zcashd.
+const (
+	codeMethodNotFound     = -32601
+	codeInvalidParams      = -32602
+	codeInvalidAddressOrID = -5
+	codeInvalidParameter   = -8
+	codeDeserialization    = -22
+	codeVerifyRejected     = -26
+	codeAlreadyInChain     = -27
+)
+
+type request struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params []any           `json:"params"`
+}
+
+type response struct {
+	Result any                 `json:"result"`
+	Error  *junocashd.RPCError `json:"error"`
+	ID     json.RawMessage     `json:"id"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.username != "" || s.password != "" {
+		if u, p, ok := r.BasicAuth(); !ok || u != s.username || p != s.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []request
+		if err := json.Unmarshal(trimmed, &reqs); err != nil {
+			writeJSON(w, http.StatusBadRequest, response{Error: &junocashd.RPCError{Code: -32700, Message: "Parse error"}})
+			return
+		}
+		resps := make([]response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = s.handle(req)
+		}
+		writeJSON(w, http.StatusOK, resps)
+		return
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Error: &junocashd.RPCError{Code: -32700, Message: "Parse error"}})
+		return
+	}
+	resp := s.handle(req)
+	status := http.StatusOK
+	switch {
+	case resp.Error == nil:
+	case resp.Error.Code == codeMethodNotFound:
+		status = http.StatusNotFound
+	default:
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) handle(req request) response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if q := s.failures[req.Method]; len(q) > 0 {
+		s.failures[req.Method] = q[1:]
+		return response{Error: q[0], ID: req.ID}
+	}
+	result, rpcErr := s.dispatch(req.Method, params(req.Params))
+	if rpcErr != nil {
+		return response{Error: rpcErr, ID: req.ID}
+	}
+	return response{Result: result, ID: req.ID}
+}
+
+func (s *Server) dispatch(method string, p params) (any, *junocashd.RPCError) {
+	switch method {
+	case "getblockchaininfo":
+		t := s.tip()
+		return junocashd.BlockchainInfo{
+			Chain:                s.chain,
+			Blocks:               t.height,
+			Headers:              t.height,
+			BestBlockHash:        t.hash,
+			VerificationProgress: 1,
+		}, nil
+	case "getblockcount":
+		return s.tip().height, nil
+	case "getbestblockhash":
+		return s.tip().hash, nil
+	case "getblockhash":
+		h, err := p.int(0)
+		if err != nil {
+			return nil, err
+		}
+		if h < 0 || h >= int64(len(s.best)) {
+			return nil, rpcError(codeInvalidParameter, "Block height out of range")
+		}
+		return s.best[h].hash, nil
+	case "getblockheader":
+		b, err := s.blockParam(p)
+		if err != nil {
+			return nil, err
+		}
+		if verbose, err := p.boolOr(1, true); err != nil {
+			return nil, err
+		} else if !verbose {
+			return hex.EncodeToString(b.header.Serialize()), nil
+		}
+		return s.header(b), nil
+	case "getblock":
+		b, err := s.blockParam(p)
+		if err != nil {
+			return nil, err
+		}
+		verbosity, err := p.intOr(1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return s.getBlock(b, verbosity)
+	case "getrawtransaction":
+		return s.getRawTransaction(p)
+	case "decoderawtransaction":
+		raw, err := p.hex(0)
+		if err != nil {
+			return nil, err
+		}
+		tx, perr := junotx.ParseTransaction(raw)
+		if perr != nil {
+			return nil, rpcError(codeDeserialization, "TX decode failed")
+		}
+		return decodeTx(tx, raw), nil
+	case "sendrawtransaction":
+		raw, err := p.hex(0)
+		if err != nil {
+			return nil, err
+		}
+		txid, rpcErr := s.accept(raw)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return txid, nil
+	case "getrawmempool":
+		verbose, err := p.boolOr(0, false)
+		if err != nil {
+			return nil, err
+		}
+		if !verbose {
+			out := make([]string, len(s.mempool))
+			for i, m := range s.mempool {
+				out[i] = m.txid
+			}
+			return out, nil
+		}
+		out := make(map[string]junocashd.MempoolEntry, len(s.mempool))
+		for _, m := range s.mempool {
+			out[m.txid] = mempoolEntry(m)
+		}
+		return out, nil
+	case "getmempoolinfo":
+		var size int64
+		for _, m := range s.mempool {
+			size += int64(len(m.raw))
+		}
+		return junocashd.MempoolInfo{Size: int64(len(s.mempool)), Bytes: size, Usage: size, FullyNotified: true}, nil
+	case "getmempoolentry":
+		txid, err := p.string(0)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range s.mempool {
+			if m.txid == txid {
+				return mempoolEntry(m), nil
+			}
+		}
+		return nil, rpcError(codeInvalidAddressOrID, "Transaction not in mempool")
+	default:
+		return nil, rpcError(codeMethodNotFound, "Method not found")
+	}
+}
+
+// accept adds a serialized transaction to the mempool.
+func (s *Server) accept(raw []byte) (string, *junocashd.RPCError) {
+	tx, err := junotx.ParseTransaction(raw)
+	if err != nil {
+		return "", rpcError(codeDeserialization, "TX decode failed")
+	}
+	txid := tx.TxID()
+	if _, ok := s.confirmed[txid]; ok {
+		return "", rpcError(codeAlreadyInChain, "transaction already in block chain")
+	}
+	for _, m := range s.mempool {
+		if m.txid == txid {
+			return txid, nil
+		}
+	}
+	if tx.IsCoinbase() {
+		return "", rpcError(codeVerifyRejected, "16: coinbase")
+	}
+	next := s.tip().height + 1
+	if expired(tx, uint32(next)) {
+		return "", rpcError(codeVerifyRejected, "16: tx-overwinter-expired")
+	}
+	s.mempool = append(s.mempool, &mempoolTx{
+		tx:     tx,
+		txid:   txid,
+		raw:    raw,
+		time:   int64(s.tip().header.Time),
+		height: s.tip().height,
+	})
+	return txid, nil
+}
+
+func (s *Server) blockParam(p params) (*block, *junocashd.RPCError) {
+	hash, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := s.blocks[hash]
+	if !ok {
+		return nil, rpcError(codeInvalidAddressOrID, "Block not found")
+	}
+	return b, nil
+}
+
+// onBest reports whether b is on the best chain.
+func (s *Server) onBest(b *block) bool {
+	return b.height < int64(len(s.best)) && s.best[b.height] == b
+}
+
+func (s *Server) header(b *block) junocashd.BlockHeader {
+	h := junocashd.BlockHeader{
+		Hash:          b.hash,
+		Confirmations: -1,
+		Height:        b.height,
+		Time:          int64(b.header.Time),
+	}
+	if b.height > 0 {
+		h.PreviousBlockHash = junotx.HashToHex(b.header.PrevBlock)
+	}
+	if s.onBest(b) {
+		h.Confirmations = s.tip().height - b.height + 1
+		if b.height+1 < int64(len(s.best)) {
+			h.NextBlockHash = s.best[b.height+1].hash
+		}
+	}
+	return h
+}
+
+func (s *Server) getBlock(b *block, verbosity int64) (any, *junocashd.RPCError) {
+	h := s.header(b)
+	switch verbosity {
+	case 0:
+		return hex.EncodeToString(b.raw), nil
+	case 1:
+		return junocashd.BlockVerbose{
+			Hash:              h.Hash,
+			Confirmations:     h.Confirmations,
+			Height:            h.Height,
+			Time:              h.Time,
+			PreviousBlockHash: h.PreviousBlockHash,
+			NextBlockHash:     h.NextBlockHash,
+			Tx:                b.txids,
+		}, nil
+	case 2:
+		txs := make([]junocashd.Transaction, len(b.txs))
+		for i, tx := range b.txs {
+			txs[i] = decodeTx(tx, tx.Serialize())
+		}
+		return junocashd.BlockWithTxs{
+			Hash:              h.Hash,
+			Confirmations:     h.Confirmations,
+			Height:            h.Height,
+			Time:              h.Time,
+			PreviousBlockHash: h.PreviousBlockHash,
+			NextBlockHash:     h.NextBlockHash,
+			Tx:                txs,
+		}, nil
+	default:
+		return nil, rpcError(codeInvalidParameter, "Verbosity must be in range from 0 to 2")
+	}
+}
+
+func (s *Server) getRawTransaction(p params) (any, *junocashd.RPCError) {
+	txid, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	verbose, err := p.intOr(1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tx *junotx.Transaction
+		b  *block
+	)
+	if b = s.confirmed[txid]; b != nil {
+		for i, id := range b.txids {
+			if id == txid {
+				tx = b.txs[i]
+			}
+		}
+	} else {
+		for _, m := range s.mempool {
+			if m.txid == txid {
+				tx = m.tx
+			}
+		}
+	}
+	if tx == nil {
+		return nil, rpcError(codeInvalidAddressOrID, "No such mempool or blockchain transaction")
+	}
+	raw := tx.Serialize()
+	if verbose == 0 {
+		return hex.EncodeToString(raw), nil
+	}
+	out := decodeTx(tx, raw)
+	if b != nil {
+		out.BlockHash = b.hash
+		out.Height = b.height
+		out.Confirmations = s.tip().height - b.height + 1
+		out.Time = int64(b.header.Time)
+		out.BlockTime = int64(b.header.Time)
+	}
+	return out, nil
+}
+
+// decodeTx renders tx the way decoderawtransaction does.
+func decodeTx(tx *junotx.Transaction, raw []byte) junocashd.Transaction {
+	out := junocashd.Transaction{
+		Hex:               hex.EncodeToString(raw),
+		TxID:              tx.TxID(),
+		AuthDigest:        tx.AuthDigestHex(),
+		Size:              int64(len(raw)),
+		Overwintered:      true,
+		Version:           int32(tx.Version),
+		VersionGroupID:    fmt.Sprintf("%08x", tx.VersionGroupID),
+		ConsensusBranchID: fmt.Sprintf("%08x", tx.ConsensusBranchID),
+		LockTime:          tx.LockTime,
+		ExpiryHeight:      tx.ExpiryHeight,
+		Vin:               []junocashd.TxIn{},
+		Vout:              []junocashd.TxOut{},
+	}
+	for _, in := range tx.TransparentInputs {
+		if tx.IsCoinbase() {
+			out.Vin = append(out.Vin, junocashd.TxIn{Coinbase: hex.EncodeToString(in.ScriptSig), Sequence: in.Sequence})
+			continue
+		}
+		out.Vin = append(out.Vin, junocashd.TxIn{
+			TxID:      junotx.HashToHex(in.PrevOut.Hash),
+			Vout:      in.PrevOut.Index,
+			ScriptSig: &junocashd.ScriptSig{Hex: hex.EncodeToString(in.ScriptSig)},
+			Sequence:  in.Sequence,
+		})
+	}
+	for i, o := range tx.TransparentOutputs {
+		out.Vout = append(out.Vout, junocashd.TxOut{
+			Value:        coins(o.Value),
+			ValueZat:     o.Value,
+			N:            uint32(i),
+			ScriptPubKey: junocashd.ScriptPubKey{Hex: hex.EncodeToString(o.ScriptPubKey), Type: "nonstandard"},
+		})
+	}
+	if b := tx.Orchard; b != nil {
+		ob := &junocashd.OrchardBundle{
+			Actions:         make([]junocashd.OrchardAction, len(b.Actions)),
+			ValueBalance:    coins(b.ValueBalance),
+			ValueBalanceZat: b.ValueBalance,
+			Flags:           &junocashd.OrchardFlags{EnableSpends: b.Flags.SpendsEnabled(), EnableOutputs: b.Flags.OutputsEnabled()},
+			Anchor:          hex.EncodeToString(b.Anchor[:]),
+			Proof:           hex.EncodeToString(b.Proof),
+			BindingSig:      hex.EncodeToString(b.BindingSig[:]),
+		}
+		for i, a := range b.Actions {
+			ob.Actions[i] = junocashd.OrchardAction{
+				CV:            hex.EncodeToString(a.CV[:]),
+				Nullifier:     hex.EncodeToString(a.Nullifier[:]),
+				RK:            hex.EncodeToString(a.RK[:]),
+				CMX:           hex.EncodeToString(a.CMX[:]),
+				EphemeralKey:  hex.EncodeToString(a.EphemeralKey[:]),
+				EncCiphertext: hex.EncodeToString(a.EncCiphertext[:]),
+				OutCiphertext: hex.EncodeToString(a.OutCiphertext[:]),
+				SpendAuthSig:  hex.EncodeToString(a.SpendAuthSig[:]),
+			}
+		}
+		out.Orchard = ob
+	}
+	return out
+}
+
+// mempoolEntry describes m. The fee is the net shielded value balance minus transparent outputs,
+// which is exact for transactions without transparent inputs.
+func mempoolEntry(m *mempoolTx) junocashd.MempoolEntry {
+	var fee int64
+	if len(m.tx.TransparentInputs) == 0 {
+		if m.tx.Orchard != nil {
+			fee += m.tx.Orchard.ValueBalance
+		}
+		if m.tx.Sapling != nil {
+			fee += m.tx.Sapling.ValueBalance
+		}
+		for _, o := range m.tx.TransparentOutputs {
+			fee -= o.Value
+		}
+	}
+	return junocashd.MempoolEntry{
+		Size:    int64(len(m.raw)),
+		Fee:     coins(fee),
+		Time:    m.time,
+		Height:  m.height,
+		Depends: []string{},
+	}
+}
+
+func rpcError(code int, msg string) *junocashd.RPCError {
+	return &junocashd.RPCError{Code: code, Message: msg}
+}
+
+// params decodes positional JSON-RPC parameters.
+type params []any
+
+func (p params) string(i int) (string, *junocashd.RPCError) {
+	if i >= len(p) {
+		return "", rpcError(codeInvalidParams, fmt.Sprintf("missing parameter %d", i))
+	}
+	s, ok := p[i].(string)
+	if !ok {
+		return "", rpcError(codeInvalidParams, fmt.Sprintf("parameter %d must be a string", i))
+	}
+	return s, nil
+}
+
+func (p params) hex(i int) ([]byte, *junocashd.RPCError) {
+	s, err := p.string(i)
+	if err != nil {
+		return nil, err
+	}
+	b, herr := hex.DecodeString(s)
+	if herr != nil {
+		return nil, rpcError(codeDeserialization, "TX decode failed")
+	}
+	return b, nil
+}
+
+func (p params) int(i int) (int64, *junocashd.RPCError) {
+	if i >= len(p) {
+		return 0, rpcError(codeInvalidParams, fmt.Sprintf("missing parameter %d", i))
+	}
+	return p.intOr(i, 0)
+}
+
+func (p params) intOr(i int, def int64) (int64, *junocashd.RPCError) {
+	if i >= len(p) || p[i] == nil {
+		return def, nil
+	}
+	switch v := p[i].(type) {
+	case float64:
+		return int64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	default:
+		return 0, rpcError(codeInvalidParams, fmt.Sprintf("parameter %d must be a number", i))
+	}
+}
+
+func (p params) boolOr(i int, def bool) (bool, *junocashd.RPCError) {
+	if i >= len(p) || p[i] == nil {
+		return def, nil
+	}
+	switch v := p[i].(type) {
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	default:
+		return false, rpcError(codeInvalidParams, fmt.Sprintf("parameter %d must be a boolean", i))
+	}
+}
+
+// coins converts zatoshi to the JUNO amounts of RPC "value" fields.
+func coins(zat int64) float64 {
+	return float64(zat) / float64(types.ZatoshiPerJUNO)
+}
@@ -0,0 +1,352 @@
+// Package junocashdtest provides an in-memory junocashd JSON-RPC server for tests.
+//
+// The server keeps a scriptable chain: tests mine blocks, add transactions to the mempool and
+// force reorgs of a chosen depth, and observe the result through the same RPC methods the SDK
+// uses against a real node (including batches). Blocks and transactions are real v5 encodings
+// built with junotx, so block hashes, txids and raw blocks are self-consistent, but nothing is
+// validated beyond parsing: proofs, signatures and balances are not checked.
+package junocashdtest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+const (
+	// BlockInterval is the time between consecutive mined blocks.
+	BlockInterval = 75 * time.Second
+
+	regtestBits = 0x200f0f0f
+)
+
+// DefaultGenesisTime is the timestamp of the genesis block unless WithGenesisTime is used.
+var DefaultGenesisTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Server is an in-memory junocashd. Its methods are safe for concurrent use with RPC traffic.
+type Server struct {
+	// URL is the JSON-RPC endpoint, for junocashd.New.
+	URL string
+
+	srv *httptest.Server
+
+	chain       string
+	genesisTime time.Time
+	username    string
+	password    string
+
+	mu sync.Mutex
+	// best is the best chain, indexed by height.
+	best []*block
+	// blocks holds every block ever mined, including those reorged out.
+	blocks  map[string]*block
+	mempool []*mempoolTx
+	// confirmed maps the txids of the best chain to their block.
+	confirmed map[string]*block
+	failures  map[string][]*junocashd.RPCError
+	nonce     uint64
+}
+
+type block struct {
+	hash   string
+	height int64
+	header junotx.BlockHeader
+	txs    []*junotx.Transaction
+	txids  []string
+	raw    []byte
+}
+
+type mempoolTx struct {
+	tx     *junotx.Transaction
+	txid   string
+	raw    []byte
+	time   int64
+	height int64
+}
+
+type Option func(*Server)
+
+// WithChain sets the chain name reported by getblockchaininfo. The default is "regtest".
+func WithChain(chain string) Option {
+	return func(s *Server) {
+		if chain != "" {
+			s.chain = chain
+		}
+	}
+}
+
+// WithGenesisTime sets the timestamp of the genesis block.
+func WithGenesisTime(t time.Time) Option {
+	return func(s *Server) {
+		if !t.IsZero() {
+			s.genesisTime = t
+		}
+	}
+}
+
+// WithRPCAuth makes the server require HTTP basic auth with the given credentials.
+func WithRPCAuth(username, password string) Option {
+	return func(s *Server) {
+		s.username, s.password = username, password
+	}
+}
+
+// NewServer starts a server whose chain holds only the genesis block. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		chain:       types.RegtestParams.Chain,
+		genesisTime: DefaultGenesisTime,
+		blocks:      map[string]*block{},
+		confirmed:   map[string]*block{},
+		failures:    map[string][]*junocashd.RPCError{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	s.mine(nil)
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() { s.srv.Close() }
+
+// Client returns a junocashd.Client for the server, with the configured credentials.
+func (s *Server) Client(opts ...junocashd.Option) *junocashd.Client {
+	return junocashd.New(s.URL, s.username, s.password, opts...)
+}
+
+// Height returns the height of the best block.
+func (s *Server) Height() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.best) - 1)
+}
+
+// BestBlockHash returns the hash of the best block.
+func (s *Server) BestBlockHash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tip().hash
+}
+
+// BlockHash returns the hash of the best-chain block at height.
+func (s *Server) BlockHash(height int64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height < 0 || height >= int64(len(s.best)) {
+		return "", false
+	}
+	return s.best[height].hash, true
+}
+
+// Cursor returns the best block as a ChainCursor.
+func (s *Server) Cursor() types.ChainCursor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.tip()
+	return types.ChainCursor{Height: t.height, Hash: t.hash}
+}
+
+// Mine mines n blocks and returns their hashes. The first block includes the whole mempool.
+func (s *Server) Mine(n int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hashes := make([]string, 0, n)
+	for range n {
+		txs := s.takeMempool()
+		hashes = append(hashes, s.mine(txs).hash)
+	}
+	return hashes
+}
+
+// AddTransaction adds tx to the mempool, with the checks of sendrawtransaction, and returns its
+// txid. Adding a transaction that is already in the mempool is a no-op.
+func (s *Server) AddTransaction(tx *junotx.Transaction) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txid, rpcErr := s.accept(tx.Serialize())
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	return txid, nil
+}
+
+// Mempool returns the txids in the mempool, in arrival order.
+func (s *Server) Mempool() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, len(s.mempool))
+	for i, m := range s.mempool {
+		out[i] = m.txid
+	}
+	return out
+}
+
+// EvictTransaction removes txid from the mempool, as if it had been evicted or replaced. It
+// reports whether the transaction was in the mempool.
+func (s *Server) EvictTransaction(txid string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.mempool {
+		if m.txid == txid {
+			s.mempool = append(s.mempool[:i], s.mempool[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Reorg disconnects the top depth blocks and mines empty blocks on the new tip, as many as
+// blocks, which may leave the best chain shorter than before. The transactions of the
+// disconnected blocks return to the mempool, so they confirm again at the next Mine. Reorg
+// returns the hashes of the new blocks; the disconnected blocks remain known to getblockheader
+// and getblock.
+func (s *Server) Reorg(depth, blocks int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if depth < 1 || depth >= len(s.best) {
+		return nil, fmt.Errorf("junocashdtest: reorg depth %d out of range 1..%d", depth, len(s.best)-1)
+	}
+	if blocks < 0 {
+		return nil, errors.New("junocashdtest: negative block count")
+	}
+
+	cut := len(s.best) - depth
+	var back []*mempoolTx
+	for _, b := range s.best[cut:] {
+		for i, tx := range b.txs {
+			delete(s.confirmed, b.txids[i])
+			if i == 0 {
+				continue // coinbase
+			}
+			back = append(back, &mempoolTx{tx: tx, txid: b.txids[i], raw: tx.Serialize(), time: int64(b.header.Time), height: b.height - 1})
+		}
+	}
+	s.best = s.best[:cut]
+	s.mempool = append(back, s.mempool...)
+
+	hashes := make([]string, 0, blocks)
+	for range blocks {
+		hashes = append(hashes, s.mine(nil).hash)
+	}
+	return hashes, nil
+}
+
+// FailNext makes the next call of method fail with err. Failures queue up per method.
+func (s *Server) FailNext(method string, err *junocashd.RPCError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method] = append(s.failures[method], err)
+}
+
+func (s *Server) tip() *block { return s.best[len(s.best)-1] }
+
+// takeMempool removes and returns the mempool transactions that are valid in the next block.
+func (s *Server) takeMempool() []*junotx.Transaction {
+	next := uint32(len(s.best))
+	var txs []*junotx.Transaction
+	for _, m := range s.mempool {
+		if !expired(m.tx, next) {
+			txs = append(txs, m.tx)
+		}
+	}
+	s.mempool = nil
+	return txs
+}
+
+// expired reports whether tx can no longer be mined at height, as zcashd's IsExpiredTx.
+func expired(tx *junotx.Transaction, height uint32) bool {
+	return tx.ExpiryHeight != 0 && height > tx.ExpiryHeight
+}
+
+// mine appends a block holding a coinbase followed by txs to the best chain.
+func (s *Server) mine(txs []*junotx.Transaction) *block {
+	s.nonce++
+	height := int64(len(s.best))
+
+	b := &block{height: height}
+	b.txs = append([]*junotx.Transaction{s.coinbase(height)}, txs...)
+	digests := make([][32]byte, len(b.txs))
+	for i, tx := range b.txs {
+		digests[i] = tx.TxIDDigest()
+		b.txids = append(b.txids, junotx.HashToHex(digests[i]))
+	}
+
+	b.header = junotx.BlockHeader{
+		Version:    4,
+		MerkleRoot: merkleRoot(digests),
+		Time:       uint32(s.genesisTime.Add(time.Duration(height) * BlockInterval).Unix()),
+		Bits:       regtestBits,
+	}
+	binary.LittleEndian.PutUint64(b.header.Nonce[:], s.nonce)
+	if height > 0 {
+		prev, _ := junotx.HashFromHex(s.tip().hash)
+		b.header.PrevBlock = prev
+	}
+	b.hash = junotx.HashToHex(sha256d(b.header.Serialize()))
+	b.raw = (&junotx.Block{Header: b.header, Transactions: b.txs}).Serialize()
+
+	s.best = append(s.best, b)
+	s.blocks[b.hash] = b
+	for _, txid := range b.txids {
+		s.confirmed[txid] = b
+	}
+	return b
+}
+
+func (s *Server) coinbase(height int64) *junotx.Transaction {
+	// BIP 34 height push, then the nonce so that blocks of competing branches differ.
+	var h [8]byte
+	binary.LittleEndian.PutUint64(h[:], uint64(height))
+	n := 8
+	for n > 1 && h[n-1] == 0 {
+		n--
+	}
+	script := append([]byte{byte(n)}, h[:n]...)
+	script = binary.LittleEndian.AppendUint64(append(script, 8), s.nonce)
+
+	return &junotx.Transaction{
+		Version:           junotx.TxVersion5,
+		VersionGroupID:    junotx.TxVersionGroupID5,
+		ConsensusBranchID: types.ConsensusBranchID,
+		ExpiryHeight:      uint32(height),
+		TransparentInputs: []junotx.TxIn{{
+			PrevOut:   junotx.OutPoint{Index: 0xffffffff},
+			ScriptSig: script,
+			Sequence:  0xffffffff,
+		}},
+		TransparentOutputs: []junotx.TxOut{{ScriptPubKey: []byte{0x6a}}},
+	}
+}
+
+func merkleRoot(leaves [][32]byte) [32]byte {
+	level := append([][32]byte(nil), leaves...)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([][32]byte, len(level)/2)
+		for i := range next {
+			next[i] = sha256d(append(level[2*i][:], level[2*i+1][:]...))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func sha256d(b []byte) [32]byte {
+	h := sha256.Sum256(b)
+	return sha256.Sum256(h[:])
+}
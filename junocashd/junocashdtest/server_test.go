@@ -0,0 +1,223 @@
+package junocashdtest_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junocashd/junocashdtest"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func orchardTx(seed byte, expiry uint32) *junotx.Transaction {
+	var a junotx.OrchardAction
+	a.Nullifier[0], a.CMX[0] = seed, seed+1
+	return &junotx.Transaction{
+		Version:           junotx.TxVersion5,
+		VersionGroupID:    junotx.TxVersionGroupID5,
+		ConsensusBranchID: types.ConsensusBranchID,
+		ExpiryHeight:      expiry,
+		Orchard: &junotx.OrchardBundle{
+			Actions:      []junotx.OrchardAction{a},
+			Flags:        junotx.OrchardFlagEnableSpends | junotx.OrchardFlagEnableOutputs,
+			ValueBalance: 10_000,
+			Proof:        []byte{1},
+		},
+	}
+}
+
+func hexTx(tx *junotx.Transaction) string { return hex.EncodeToString(tx.Serialize()) }
+
+func TestServer_ChainAndBlocks(t *testing.T) {
+	t.Parallel()
+	srv := junocashdtest.NewServer()
+	t.Cleanup(srv.Close)
+	cli := srv.Client()
+	ctx := context.Background()
+
+	hashes := srv.Mine(3)
+	info, err := cli.GetBlockchainInfo(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockchainInfo: %v", err)
+	}
+	if info.Chain != "regtest" || info.Blocks != 3 || info.BestBlockHash != hashes[2] {
+		t.Fatalf("info=%+v", info)
+	}
+
+	headers, err := cli.GetBlockHeaders(ctx, hashes)
+	if err != nil {
+		t.Fatalf("GetBlockHeaders: %v", err)
+	}
+	if headers[1].PreviousBlockHash != hashes[0] || headers[1].NextBlockHash != hashes[2] || headers[0].Confirmations != 3 {
+		t.Fatalf("headers=%+v", headers)
+	}
+	if headers[2].Time-headers[1].Time != int64(junocashdtest.BlockInterval.Seconds()) {
+		t.Fatalf("block times %d, %d", headers[1].Time, headers[2].Time)
+	}
+
+	raw, err := cli.GetBlockRaw(ctx, hashes[1])
+	if err != nil {
+		t.Fatalf("GetBlockRaw: %v", err)
+	}
+	blk, err := junotx.ParseBlock(raw)
+	if err != nil {
+		t.Fatalf("ParseBlock: %v", err)
+	}
+	first := sha256.Sum256(blk.Header.Serialize())
+	if junotx.HashToHex(sha256.Sum256(first[:])) != hashes[1] {
+		t.Fatalf("header does not hash to block hash")
+	}
+	if len(blk.Transactions) != 1 || !blk.Transactions[0].IsCoinbase() {
+		t.Fatalf("want a lone coinbase, got %d txs", len(blk.Transactions))
+	}
+
+	if _, err := cli.GetBlockHash(ctx, 4); err == nil {
+		t.Fatalf("expected out-of-range error")
+	}
+	var rpcErr *junocashd.RPCError
+	if _, err := cli.GetBlockHeader(ctx, "00"); !errors.As(err, &rpcErr) || rpcErr.Code != -5 {
+		t.Fatalf("err=%v, want -5", err)
+	}
+}
+
+func TestServer_MempoolAndConfirmations(t *testing.T) {
+	t.Parallel()
+	srv := junocashdtest.NewServer()
+	t.Cleanup(srv.Close)
+	cli := srv.Client()
+	ctx := context.Background()
+	srv.Mine(10)
+
+	tx := orchardTx(1, 100)
+	txid, err := cli.SendRawTransaction(ctx, hexTx(tx))
+	if err != nil {
+		t.Fatalf("SendRawTransaction: %v", err)
+	}
+	if txid != tx.TxID() {
+		t.Fatalf("txid=%s", txid)
+	}
+	entry, err := cli.GetMempoolEntry(ctx, txid)
+	if err != nil {
+		t.Fatalf("GetMempoolEntry: %v", err)
+	}
+	if entry.Fee != 0.0001 || entry.Height != 10 {
+		t.Fatalf("entry=%+v", entry)
+	}
+
+	other, err := srv.AddTransaction(orchardTx(2, 0))
+	if err != nil {
+		t.Fatalf("AddTransaction: %v", err)
+	}
+	pool, err := cli.GetRawMempool(ctx)
+	if err != nil || len(pool) != 2 || pool[0] != txid || pool[1] != other {
+		t.Fatalf("mempool=%v err=%v", pool, err)
+	}
+	if !srv.EvictTransaction(other) || srv.EvictTransaction(other) {
+		t.Fatalf("EvictTransaction")
+	}
+
+	hashes := srv.Mine(2)
+	verbose, err := cli.GetRawTransactionVerbose(ctx, txid)
+	if err != nil {
+		t.Fatalf("GetRawTransactionVerbose: %v", err)
+	}
+	if verbose.BlockHash != hashes[0] || verbose.Height != 11 || verbose.Confirmations != 2 {
+		t.Fatalf("tx block fields=%+v", verbose)
+	}
+	if len(verbose.Orchard.Actions) != 1 || verbose.Orchard.ValueBalanceZat != 10_000 || verbose.ExpiryHeight != 100 {
+		t.Fatalf("tx=%+v", verbose)
+	}
+	info, err := cli.GetMempoolInfo(ctx)
+	if err != nil || info.Size != 0 {
+		t.Fatalf("mempool info=%+v err=%v", info, err)
+	}
+
+	var rpcErr *junocashd.RPCError
+	if _, err := cli.SendRawTransaction(ctx, hexTx(tx)); !errors.As(err, &rpcErr) || rpcErr.Code != -27 {
+		t.Fatalf("resend err=%v, want -27", err)
+	}
+	if _, err := cli.SendRawTransaction(ctx, hexTx(orchardTx(3, 12))); !errors.As(err, &rpcErr) || rpcErr.Code != -26 {
+		t.Fatalf("expired err=%v, want -26", err)
+	}
+	if _, err := cli.SendRawTransaction(ctx, "0500"); !errors.As(err, &rpcErr) || rpcErr.Code != -22 {
+		t.Fatalf("decode err=%v, want -22", err)
+	}
+}
+
+func TestServer_Reorg(t *testing.T) {
+	t.Parallel()
+	srv := junocashdtest.NewServer()
+	t.Cleanup(srv.Close)
+	cli := srv.Client()
+	ctx := context.Background()
+	srv.Mine(5)
+
+	txid, err := srv.AddTransaction(orchardTx(1, 0))
+	if err != nil {
+		t.Fatalf("AddTransaction: %v", err)
+	}
+	mined := srv.Mine(2)
+
+	replaced, err := srv.Reorg(2, 3)
+	if err != nil {
+		t.Fatalf("Reorg: %v", err)
+	}
+	if srv.Height() != 8 || srv.BestBlockHash() != replaced[2] {
+		t.Fatalf("height=%d best=%s", srv.Height(), srv.BestBlockHash())
+	}
+	if got := srv.Mempool(); len(got) != 1 || got[0] != txid {
+		t.Fatalf("mempool=%v, want reorged tx back", got)
+	}
+	stale, err := cli.GetBlockHeader(ctx, mined[0])
+	if err != nil {
+		t.Fatalf("GetBlockHeader(stale): %v", err)
+	}
+	if stale.Confirmations != -1 {
+		t.Fatalf("stale confirmations=%d", stale.Confirmations)
+	}
+	fresh, err := cli.GetBlockHeader(ctx, replaced[0])
+	if err != nil {
+		t.Fatalf("GetBlockHeader: %v", err)
+	}
+	if fresh.Height != 6 || fresh.PreviousBlockHash != stale.PreviousBlockHash {
+		t.Fatalf("fresh=%+v stale=%+v", fresh, stale)
+	}
+
+	if _, err := srv.Reorg(9, 1); err == nil {
+		t.Fatalf("expected depth error")
+	}
+	if _, err := srv.Reorg(3, 1); err != nil {
+		t.Fatalf("shortening Reorg: %v", err)
+	}
+	if srv.Height() != 6 {
+		t.Fatalf("height=%d", srv.Height())
+	}
+}
+
+func TestServer_FailNextAndAuth(t *testing.T) {
+	t.Parallel()
+	srv := junocashdtest.NewServer(junocashdtest.WithRPCAuth("user", "pass"), junocashdtest.WithChain("main"))
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+
+	if _, err := junocashd.New(srv.URL, "user", "wrong").GetBlockCount(ctx); err == nil {
+		t.Fatalf("expected auth error")
+	}
+
+	cli := srv.Client()
+	srv.FailNext("getblockcount", &junocashd.RPCError{Code: -28, Message: "Loading block index..."})
+	var rpcErr *junocashd.RPCError
+	if _, err := cli.GetBlockCount(ctx); !errors.As(err, &rpcErr) || rpcErr.Code != -28 {
+		t.Fatalf("err=%v, want injected -28", err)
+	}
+	if n, err := cli.GetBlockCount(ctx); err != nil || n != 0 {
+		t.Fatalf("count=%d err=%v", n, err)
+	}
+	if info, err := cli.GetBlockchainInfo(ctx); err != nil || info.Chain != "main" {
+		t.Fatalf("info=%+v err=%v", info, err)
+	}
+}
@@ -2,6 +2,8 @@ package junocashd
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
 )
 
 func (c *Client) GetBlockchainInfo(ctx context.Context) (*BlockchainInfo, error) {
@@ -52,6 +54,26 @@ func (c *Client) GetBlockVerbose(ctx context.Context, blockHash string) (*BlockV
 	return &out, nil
 }
 
+func (c *Client) GetBlockWithTxs(ctx context.Context, blockHash string) (*BlockWithTxs, error) {
+	var out BlockWithTxs
+	if err := c.callStream(ctx, "getblock", []any{blockHash, 2}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) GetBlockRaw(ctx context.Context, blockHash string) ([]byte, error) {
+	var out string
+	if err := c.callStream(ctx, "getblock", []any{blockHash, 0}, &out); err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(out)
+	if err != nil {
+		return nil, fmt.Errorf("junocashd: decode block hex: %w", err)
+	}
+	return raw, nil
+}
+
 func (c *Client) GetRawTransactionHex(ctx context.Context, txid string) (string, error) {
 	var out string
 	if err := c.Call(ctx, "getrawtransaction", []any{txid, 0}, &out); err != nil {
@@ -60,6 +82,22 @@ func (c *Client) GetRawTransactionHex(ctx context.Context, txid string) (string,
 	return out, nil
 }
 
+func (c *Client) GetRawTransactionVerbose(ctx context.Context, txid string) (*Transaction, error) {
+	var out Transaction
+	if err := c.Call(ctx, "getrawtransaction", []any{txid, 1}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) DecodeRawTransaction(ctx context.Context, txHex string) (*Transaction, error) {
+	var out Transaction
+	if err := c.Call(ctx, "decoderawtransaction", []any{txHex}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *Client) SendRawTransaction(ctx context.Context, txHex string) (string, error) {
 	var out string
 	if err := c.Call(ctx, "sendrawtransaction", []any{txHex}, &out); err != nil {
@@ -67,3 +105,38 @@ func (c *Client) SendRawTransaction(ctx context.Context, txHex string) (string,
 	}
 	return out, nil
 }
+
+func (c *Client) GetRawMempool(ctx context.Context) ([]string, error) {
+	var out []string
+	if err := c.Call(ctx, "getrawmempool", []any{false}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) GetRawMempoolVerbose(ctx context.Context) (map[string]MempoolEntry, error) {
+	var out map[string]MempoolEntry
+	if err := c.Call(ctx, "getrawmempool", []any{true}, &out); err != nil {
+		return nil, err
+	}
+	if out == nil {
+		out = map[string]MempoolEntry{}
+	}
+	return out, nil
+}
+
+func (c *Client) GetMempoolInfo(ctx context.Context) (*MempoolInfo, error) {
+	var out MempoolInfo
+	if err := c.Call(ctx, "getmempoolinfo", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) GetMempoolEntry(ctx context.Context, txid string) (*MempoolEntry, error) {
+	var out MempoolEntry
+	if err := c.Call(ctx, "getmempoolentry", []any{txid}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
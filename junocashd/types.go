@@ -32,3 +32,139 @@ type BlockVerbose struct {
 	NextBlockHash     string   `json:"nextblockhash,omitempty"`
 	Tx                []string `json:"tx"`
 }
+
+type BlockWithTxs struct {
+	Hash              string        `json:"hash"`
+	Confirmations     int64         `json:"confirmations,omitempty"`
+	Height            int64         `json:"height"`
+	Time              int64         `json:"time"`
+	PreviousBlockHash string        `json:"previousblockhash,omitempty"`
+	NextBlockHash     string        `json:"nextblockhash,omitempty"`
+	Tx                []Transaction `json:"tx"`
+}
+
+type MempoolInfo struct {
+	Size          int64 `json:"size"`
+	Bytes         int64 `json:"bytes"`
+	Usage         int64 `json:"usage"`
+	MaxMempool    int64 `json:"maxmempool,omitempty"`
+	FullyNotified bool  `json:"fullyNotified,omitempty"`
+}
+
+type MempoolEntry struct {
+	Size             int64    `json:"size"`
+	Fee              float64  `json:"fee"`
+	ModifiedFee      float64  `json:"modifiedfee,omitempty"`
+	Time             int64    `json:"time"`
+	Height           int64    `json:"height"`
+	StartingPriority float64  `json:"startingpriority,omitempty"`
+	CurrentPriority  float64  `json:"currentpriority,omitempty"`
+	DescendantCount  int64    `json:"descendantcount,omitempty"`
+	DescendantSize   int64    `json:"descendantsize,omitempty"`
+	DescendantFees   int64    `json:"descendantfees,omitempty"`
+	Depends          []string `json:"depends"`
+}
+
+type Transaction struct {
+	Hex               string         `json:"hex,omitempty"`
+	TxID              string         `json:"txid"`
+	AuthDigest        string         `json:"authdigest,omitempty"`
+	Size              int64          `json:"size,omitempty"`
+	Overwintered      bool           `json:"overwintered,omitempty"`
+	Version           int32          `json:"version"`
+	VersionGroupID    string         `json:"versiongroupid,omitempty"`
+	ConsensusBranchID string         `json:"consensusbranchid,omitempty"`
+	LockTime          uint32         `json:"locktime"`
+	ExpiryHeight      uint32         `json:"expiryheight,omitempty"`
+	Vin               []TxIn         `json:"vin"`
+	Vout              []TxOut        `json:"vout"`
+	Orchard           *OrchardBundle `json:"orchard,omitempty"`
+	BlockHash         string         `json:"blockhash,omitempty"`
+	Height            int64          `json:"height,omitempty"`
+	Confirmations     int64          `json:"confirmations,omitempty"`
+	Time              int64          `json:"time,omitempty"`
+	BlockTime         int64          `json:"blocktime,omitempty"`
+}
+
+type TxIn struct {
+	Coinbase  string     `json:"coinbase,omitempty"`
+	TxID      string     `json:"txid,omitempty"`
+	Vout      uint32     `json:"vout,omitempty"`
+	ScriptSig *ScriptSig `json:"scriptSig,omitempty"`
+	Sequence  uint32     `json:"sequence"`
+}
+
+type ScriptSig struct {
+	Asm string `json:"asm"`
+	Hex string `json:"hex"`
+}
+
+type TxOut struct {
+	Value        float64      `json:"value"`
+	ValueZat     int64        `json:"valueZat"`
+	N            uint32       `json:"n"`
+	ScriptPubKey ScriptPubKey `json:"scriptPubKey"`
+}
+
+type ScriptPubKey struct {
+	Asm       string   `json:"asm"`
+	Hex       string   `json:"hex"`
+	ReqSigs   int64    `json:"reqSigs,omitempty"`
+	Type      string   `json:"type"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+type OrchardBundle struct {
+	Actions         []OrchardAction `json:"actions"`
+	ValueBalance    float64         `json:"valueBalance"`
+	ValueBalanceZat int64           `json:"valueBalanceZat"`
+	Flags           *OrchardFlags   `json:"flags,omitempty"`
+	Anchor          string          `json:"anchor,omitempty"`
+	Proof           string          `json:"proof,omitempty"`
+	BindingSig      string          `json:"bindingSig,omitempty"`
+}
+
+type OrchardFlags struct {
+	EnableSpends  bool `json:"enableSpends"`
+	EnableOutputs bool `json:"enableOutputs"`
+}
+
+type OrchardAction struct {
+	CV            string `json:"cv"`
+	Nullifier     string `json:"nullifier"`
+	RK            string `json:"rk"`
+	CMX           string `json:"cmx"`
+	EphemeralKey  string `json:"ephemeralKey"`
+	EncCiphertext string `json:"encCiphertext"`
+	OutCiphertext string `json:"outCiphertext"`
+	SpendAuthSig  string `json:"spendAuthSig,omitempty"`
+}
+
+func (b *OrchardBundle) ActionCount() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.Actions)
+}
+
+func (b *OrchardBundle) Nullifiers() []string {
+	if b == nil {
+		return nil
+	}
+	out := make([]string, len(b.Actions))
+	for i, a := range b.Actions {
+		out[i] = a.Nullifier
+	}
+	return out
+}
+
+func (b *OrchardBundle) CMXs() []string {
+	if b == nil {
+		return nil
+	}
+	out := make([]string, len(b.Actions))
+	for i, a := range b.Actions {
+		out[i] = a.CMX
+	}
+	return out
+}
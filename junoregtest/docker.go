@@ -0,0 +1,157 @@
+//go:build docker
+
+package junoregtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	build "github.com/docker/docker/api/types/build"
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultJunocashVersion = "0.9.12"
+	dockerDatadir          = "/data"
+	dockerRPCPort          = 8232
+	dockerP2PPort          = 18344
+)
+
+// DockerConfig configures StartDocker.
+type DockerConfig struct {
+	// Image is a prebuilt junocashd image. Empty means building docker/junocashd/Dockerfile of
+	// this module.
+	Image string
+	// JunocashVersion is the release the Dockerfile installs. Empty means 0.9.12.
+	JunocashVersion string
+	// RPCUser and RPCPassword default to "rpcuser" and "rpcpass".
+	RPCUser     string
+	RPCPassword string
+	// Args are extra junocashd arguments.
+	Args []string
+	// Listen accepts P2P connections on the container's address so that containers on the same
+	// Docker network can peer with this node. Without it the node runs with -listen=0.
+	Listen bool
+}
+
+// StartDocker runs a regtest junocashd (with -txindex=1) in a container and waits for its RPC
+// port. Stop terminates the container.
+func StartDocker(ctx context.Context, cfg DockerConfig) (*Node, error) {
+	version := cfg.JunocashVersion
+	if version == "" {
+		version = defaultJunocashVersion
+	}
+	rpcUser := cfg.RPCUser
+	if rpcUser == "" {
+		rpcUser = "rpcuser"
+	}
+	rpcPass := cfg.RPCPassword
+	if rpcPass == "" {
+		rpcPass = "rpcpass"
+	}
+	port := nat.Port(fmt.Sprintf("%d/tcp", dockerRPCPort))
+	p2p := []string{"-listen=0"}
+	if cfg.Listen {
+		p2p = []string{"-listen=1", fmt.Sprintf("-port=%d", dockerP2PPort), "-discover=0", "-dnsseed=0"}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:         cfg.Image,
+		ImagePlatform: "linux/amd64",
+		ExposedPorts:  []string{string(port)},
+		Cmd: append(append([]string{
+			"-regtest",
+			"-server=1",
+			"-txindex=1",
+			"-daemon=0",
+			"-printtoconsole=1",
+			"-datadir=" + dockerDatadir,
+			"-rpcbind=0.0.0.0",
+			"-rpcallowip=0.0.0.0/0",
+			fmt.Sprintf("-rpcport=%d", dockerRPCPort),
+			"-rpcuser=" + rpcUser,
+			"-rpcpassword=" + rpcPass,
+		}, p2p...), cfg.Args...),
+		WaitingFor: wait.ForListeningPort(port).WithStartupTimeout(60 * time.Second),
+	}
+	if cfg.Image == "" {
+		req.FromDockerfile = testcontainers.FromDockerfile{
+			Context:    moduleRoot(),
+			Dockerfile: "docker/junocashd/Dockerfile",
+			BuildArgs: map[string]*string{
+				"JUNOCASH_VERSION": &version,
+			},
+			BuildOptionsModifier: func(opts *build.ImageBuildOptions) {
+				opts.Platform = "linux/amd64"
+				opts.Version = build.BuilderBuildKit
+			},
+		}
+	}
+	if os.Getenv("JUNO_TEST_LOG") != "" {
+		req.FromDockerfile.BuildLogWriter = os.Stdout
+		req.LogConsumerCfg = &testcontainers.LogConsumerConfig{
+			Consumers: []testcontainers.LogConsumer{&testcontainers.StdoutLogConsumer{}},
+		}
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("junoregtest: start container: %w", err)
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		_ = c.Terminate(context.Background())
+		return nil, fmt.Errorf("junoregtest: container host: %w", err)
+	}
+
+	n := &Node{
+		Datadir:       dockerDatadir,
+		RPCURL:        "http://" + host,
+		RPCUser:       rpcUser,
+		RPCPassword:   rpcPass,
+		ContainerID:   c.GetContainerID(),
+		cliPath:       "junocash-cli",
+		dockerDatadir: dockerDatadir,
+		dockerRPCPort: dockerRPCPort,
+		terminate:     func(ctx context.Context) error { return c.Terminate(ctx) },
+	}
+	if cfg.Listen {
+		n.dockerP2PPort = dockerP2PPort
+	}
+	if err := n.refreshDockerEndpoint(ctx); err != nil {
+		_ = c.Terminate(context.Background())
+		return nil, err
+	}
+	if err := n.waitForRPC(ctx, rpcReadyTimeout); err != nil {
+		_ = c.Terminate(context.Background())
+		return nil, err
+	}
+	return n, nil
+}
+
+func moduleRoot() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return "."
+	}
+	return filepath.Clean(filepath.Join(filepath.Dir(file), ".."))
+}
+
+// StartDockerNetwork runs count nodes with StartDocker, each listening for peers on the default
+// Docker network, and connects every pair. cfg.Listen is implied.
+func StartDockerNetwork(ctx context.Context, count int, cfg DockerConfig) (*Network, error) {
+	cfg.Listen = true
+	return startNetwork(ctx, count, func(ctx context.Context) (*Node, error) {
+		return StartDocker(ctx, cfg)
+	})
+}
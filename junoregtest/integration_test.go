@@ -0,0 +1,122 @@
+//go:build integration
+
+package junoregtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
+)
+
+func TestNode_FundSnapshotRestore_Integration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	node, err := junoregtest.Start(ctx, junoregtest.Config{})
+	if err != nil {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
+			t.Skip("junocashd not found in PATH")
+		}
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = node.Stop(context.Background()) }()
+	cli := node.Client()
+
+	acct, err := node.NewOrchardAccount(ctx)
+	if err != nil {
+		t.Fatalf("NewOrchardAccount: %v", err)
+	}
+	if acct.Address == "" || acct.UFVK == "" {
+		t.Fatalf("account=%+v", acct)
+	}
+	ufvk, err := node.ExportUFVK(ctx, acct.Address)
+	if err != nil || ufvk != acct.UFVK {
+		t.Fatalf("ExportUFVK=%q err=%v, want %q", ufvk, err, acct.UFVK)
+	}
+
+	txid, err := node.Fund(ctx, acct.Address, 100_000_000)
+	if err != nil {
+		t.Fatalf("Fund: %v", err)
+	}
+	funded, err := cli.GetBlockCount(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockCount: %v", err)
+	}
+	if funded <= junoregtest.CoinbaseMaturity {
+		t.Fatalf("height=%d, want past coinbase maturity", funded)
+	}
+
+	snap, err := node.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if _, err := node.Generate(ctx, 5); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if err := node.Restore(ctx, snap); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	cli = node.Client()
+	height, err := cli.GetBlockCount(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockCount: %v", err)
+	}
+	if height != funded {
+		t.Fatalf("height after restore=%d want %d", height, funded)
+	}
+	if err := node.WaitForTx(ctx, txid, 1); err != nil {
+		t.Fatalf("WaitForTx after restore: %v", err)
+	}
+}
+
+func TestNetwork_Reorg_Integration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	w, err := junoregtest.StartNetwork(ctx, 3, junoregtest.Config{})
+	if err != nil {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
+			t.Skip("junocashd not found in PATH")
+		}
+		t.Fatalf("StartNetwork: %v", err)
+	}
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	checkNetworkReorg(ctx, t, w)
+}
+
+func checkNetworkReorg(ctx context.Context, t *testing.T, w *junoregtest.Network) {
+	t.Helper()
+	if _, err := w.Nodes[0].Generate(ctx, 10); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if err := w.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	ev, err := w.Reorg(ctx, 3)
+	if err != nil {
+		t.Fatalf("Reorg: %v", err)
+	}
+	if ev.To.Height != 10 || ev.From.Height != 13 {
+		t.Fatalf("reorg=%+v, want 13 -> 10", ev)
+	}
+	for i, n := range w.Nodes {
+		cli := n.Client()
+		height, err := cli.GetBlockCount(ctx)
+		if err != nil || height != 14 {
+			t.Fatalf("node %d height=%d err=%v, want 14", i, height, err)
+		}
+		fork, err := cli.GetBlockHash(ctx, ev.To.Height)
+		if err != nil || fork != ev.To.Hash {
+			t.Fatalf("node %d fork hash=%s err=%v, want %s", i, fork, err, ev.To.Hash)
+		}
+		if hdr, err := cli.GetBlockHeader(ctx, ev.From.Hash); err == nil && hdr.Confirmations >= 0 {
+			t.Fatalf("node %d: orphaned tip still on the best chain", i)
+		}
+	}
+}
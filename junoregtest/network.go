@@ -0,0 +1,244 @@
+package junoregtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+// rpcNodeNotConnected is the RPC error code of disconnectnode for a peer that is not connected.
+const rpcNodeNotConnected = -29
+
+// Network is a set of regtest nodes peered over P2P. Tests Split it, mine competing chains on
+// each node and Join it again; the nodes on shorter chains then reorg onto the longest one.
+type Network struct {
+	Nodes []*Node
+}
+
+// StartNetwork runs count local nodes with Start, each listening for peers on 127.0.0.1, and
+// connects every pair. cfg.Listen is implied and JUNO_TEST_RPC_URL is ignored, since a network
+// needs nodes of its own.
+func StartNetwork(ctx context.Context, count int, cfg Config) (*Network, error) {
+	cfg.Listen = true
+	return startNetwork(ctx, count, func(ctx context.Context) (*Node, error) {
+		return startLocal(ctx, cfg)
+	})
+}
+
+func startNetwork(ctx context.Context, count int, start func(context.Context) (*Node, error)) (*Network, error) {
+	if count < 2 {
+		return nil, fmt.Errorf("junoregtest: network of %d nodes, need at least 2", count)
+	}
+	w := &Network{}
+	for range count {
+		n, err := start(ctx)
+		if err != nil {
+			_ = w.Stop(context.Background())
+			return nil, err
+		}
+		w.Nodes = append(w.Nodes, n)
+	}
+	if err := w.Join(ctx); err != nil {
+		_ = w.Stop(context.Background())
+		return nil, err
+	}
+	return w, nil
+}
+
+// Stop stops every node of the network.
+func (w *Network) Stop(ctx context.Context) error {
+	var errs []error
+	for _, n := range w.Nodes {
+		errs = append(errs, n.Stop(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// Join connects every pair of nodes and waits until they agree on the best block.
+func (w *Network) Join(ctx context.Context) error {
+	for i, a := range w.Nodes {
+		for _, b := range w.Nodes[i+1:] {
+			if err := a.Connect(ctx, b); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Sync(ctx)
+}
+
+// Split disconnects every pair of nodes and waits until no node has peers left.
+func (w *Network) Split(ctx context.Context) error {
+	for i, a := range w.Nodes {
+		for _, b := range w.Nodes[i+1:] {
+			if err := a.Disconnect(ctx, b); err != nil {
+				return err
+			}
+		}
+	}
+	for _, n := range w.Nodes {
+		cli := n.Client()
+		err := poll(ctx, "peers to disconnect", func() (bool, error) {
+			var count int
+			if err := cli.Call(ctx, "getconnectioncount", []any{}, &count); err != nil {
+				return false, err
+			}
+			return count == 0, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sync waits until every node has the same best block.
+func (w *Network) Sync(ctx context.Context) error {
+	return poll(ctx, "nodes to sync", func() (bool, error) {
+		var first string
+		for i, n := range w.Nodes {
+			hash, err := n.Client().GetBestBlockHash(ctx)
+			if err != nil {
+				return false, err
+			}
+			if i == 0 {
+				first = hash
+			} else if hash != first {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// Reorg makes Nodes[0] reorg depth blocks. It splits the network, mines depth blocks on
+// Nodes[0] and depth+1 blocks on Nodes[1], then joins the network again so that every node
+// adopts the chain of Nodes[1]. The returned event runs from the orphaned tip of Nodes[0] to
+// the fork point, like the ones junocashd.ChainFollower emits.
+//
+// Transactions in the mempool of both nodes are mined on both sides. To have a transaction
+// reorged out, Split the network, send it from Nodes[0] and mine the chains by hand.
+func (w *Network) Reorg(ctx context.Context, depth int) (types.ReorgEvent, error) {
+	if depth < 1 {
+		return types.ReorgEvent{}, fmt.Errorf("junoregtest: invalid reorg depth %d", depth)
+	}
+	if len(w.Nodes) < 2 {
+		return types.ReorgEvent{}, errors.New("junoregtest: reorg needs at least 2 nodes")
+	}
+	loser, winner := w.Nodes[0], w.Nodes[1]
+
+	if err := w.Sync(ctx); err != nil {
+		return types.ReorgEvent{}, err
+	}
+	fork, err := tip(ctx, loser.Client())
+	if err != nil {
+		return types.ReorgEvent{}, err
+	}
+	if err := w.Split(ctx); err != nil {
+		return types.ReorgEvent{}, err
+	}
+	orphaned, err := loser.Generate(ctx, depth)
+	if err != nil {
+		return types.ReorgEvent{}, err
+	}
+	won, err := winner.Generate(ctx, depth+1)
+	if err != nil {
+		return types.ReorgEvent{}, err
+	}
+	if err := w.Join(ctx); err != nil {
+		return types.ReorgEvent{}, err
+	}
+	if hash, err := loser.Client().GetBestBlockHash(ctx); err != nil || hash != won[len(won)-1] {
+		return types.ReorgEvent{}, fmt.Errorf("junoregtest: node did not reorg: tip %s, want %s (err: %v)", hash, won[len(won)-1], err)
+	}
+	return types.ReorgEvent{
+		From: types.ChainCursor{Height: fork.Height + int64(depth), Hash: orphaned[len(orphaned)-1]},
+		To:   fork,
+	}, nil
+}
+
+// Connect opens a P2P connection from n to other with addnode and waits for the handshake.
+// Both nodes must listen.
+func (n *Node) Connect(ctx context.Context, other *Node) error {
+	if n.P2PAddr == "" || other.P2PAddr == "" {
+		return errors.New("junoregtest: connect: both nodes must listen")
+	}
+	cli := n.Client()
+	if err := cli.Call(ctx, "addnode", []any{other.P2PAddr, "onetry"}, nil); err != nil {
+		return fmt.Errorf("junoregtest: addnode %s: %w", other.P2PAddr, err)
+	}
+	return poll(ctx, "connection to "+other.P2PAddr, func() (bool, error) {
+		peer, err := n.outboundPeer(ctx, other.P2PAddr)
+		return peer != nil && peer.Version > 0, err
+	})
+}
+
+// Disconnect drops the connection that Connect opened from n to other and waits until it is
+// gone. It does nothing if they are not connected.
+func (n *Node) Disconnect(ctx context.Context, other *Node) error {
+	cli := n.Client()
+	err := cli.Call(ctx, "disconnectnode", []any{other.P2PAddr}, nil)
+	var rpcErr *junocashd.RPCError
+	if err != nil && !(errors.As(err, &rpcErr) && rpcErr.Code == rpcNodeNotConnected) {
+		return fmt.Errorf("junoregtest: disconnectnode %s: %w", other.P2PAddr, err)
+	}
+	return poll(ctx, "disconnection from "+other.P2PAddr, func() (bool, error) {
+		peer, err := n.outboundPeer(ctx, other.P2PAddr)
+		return peer == nil, err
+	})
+}
+
+type peerInfo struct {
+	Addr    string `json:"addr"`
+	Inbound bool   `json:"inbound"`
+	Version int    `json:"version"`
+}
+
+// outboundPeer returns the peer n connected to at addr, or nil.
+func (n *Node) outboundPeer(ctx context.Context, addr string) (*peerInfo, error) {
+	var peers []peerInfo
+	if err := n.Client().Call(ctx, "getpeerinfo", []any{}, &peers); err != nil {
+		return nil, fmt.Errorf("junoregtest: getpeerinfo: %w", err)
+	}
+	for i := range peers {
+		if !peers[i].Inbound && peers[i].Addr == addr {
+			return &peers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func tip(ctx context.Context, cli *junocashd.Client) (types.ChainCursor, error) {
+	height, err := cli.GetBlockCount(ctx)
+	if err != nil {
+		return types.ChainCursor{}, fmt.Errorf("junoregtest: getblockcount: %w", err)
+	}
+	hash, err := cli.GetBlockHash(ctx, height)
+	if err != nil {
+		return types.ChainCursor{}, fmt.Errorf("junoregtest: getblockhash: %w", err)
+	}
+	return types.ChainCursor{Height: height, Hash: hash}, nil
+}
+
+// poll calls done every pollInterval until it reports true or fails, or ctx ends.
+func poll(ctx context.Context, what string, done func() (bool, error)) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("junoregtest: wait for %s: %w", what, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
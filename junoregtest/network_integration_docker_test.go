@@ -0,0 +1,24 @@
+//go:build integration && docker
+
+package junoregtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
+)
+
+func TestDockerNetwork_Reorg_Integration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	w, err := junoregtest.StartDockerNetwork(ctx, 2, junoregtest.DockerConfig{})
+	if err != nil {
+		t.Fatalf("StartDockerNetwork: %v", err)
+	}
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	checkNetworkReorg(ctx, t, w)
+}
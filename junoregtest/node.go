@@ -0,0 +1,391 @@
+// Package junoregtest starts and drives junocashd regtest nodes for integration tests.
+//
+// Start runs a local junocashd binary, or attaches to an already running node when
+// JUNO_TEST_RPC_URL is set (see Start). StartDocker, built with the docker build tag, runs the
+// node in a container built from docker/junocashd. Once a node is up, tests mine blocks, create
+// and fund Orchard accounts, export their UFVKs and snapshot and restore the datadir to reset
+// state between tests. StartNetwork and StartDockerNetwork run several nodes that peer over P2P,
+// so tests can split them, mine competing chains and join them again for a real reorg.
+package junoregtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+)
+
+const rpcReadyTimeout = 25 * time.Second
+
+// ErrJunocashdNotFound is returned by Start when no junocashd binary is configured or in PATH.
+var ErrJunocashdNotFound = errors.New("junoregtest: junocashd not found")
+
+// Config configures Start.
+type Config struct {
+	// JunocashdPath is the junocashd binary. Empty means junocashd from PATH.
+	JunocashdPath string
+	// JunocashCLI is the junocash-cli binary. Empty means junocash-cli from PATH.
+	JunocashCLI string
+	// RPCUser defaults to "rpcuser"; RPCPassword defaults to a random password.
+	RPCUser     string
+	RPCPassword string
+	// Args are extra junocashd arguments, e.g. "-txindex=1".
+	Args []string
+	// Listen accepts P2P connections on 127.0.0.1 so that other nodes can peer with this one.
+	// Without it the node runs with -listen=0.
+	Listen bool
+}
+
+// Node is a running regtest node. Its exported fields describe how to reach it.
+type Node struct {
+	Datadir     string
+	RPCURL      string
+	RPCPort     int
+	RPCUser     string
+	RPCPassword string
+	// ContainerID is set when the node runs in Docker.
+	ContainerID string
+	// P2PAddr is the host:port other nodes connect to. It is empty unless the node listens.
+	P2PAddr string
+
+	cliPath string
+	bin     string
+	args    []string
+	cmd     *exec.Cmd
+
+	dockerDatadir string
+	dockerRPCPort int
+	dockerP2PPort int
+	// terminate removes the container of a node started by StartDocker.
+	terminate func(ctx context.Context) error
+
+	mu        sync.Mutex
+	snapshots []*Snapshot
+	stopOnce  sync.Once
+}
+
+// Start runs junocashd in regtest mode on free local ports with a fresh datadir, and waits for
+// its RPC server. The node outlives ctx; call Stop when done.
+//
+// When JUNO_TEST_RPC_URL is set, Start instead attaches to the node at that URL, which must run
+// in the Docker container JUNO_TEST_JUNOCASHD_CONTAINER. JUNO_TEST_RPC_USER, JUNO_TEST_RPC_PASS,
+// JUNO_TEST_JUNOCASHD_DATADIR (default /data) and JUNO_TEST_JUNOCASHD_RPC_PORT (default 8232)
+// describe it. JUNO_TEST_LOG sends the node's console output to stdout.
+func Start(ctx context.Context, cfg Config) (*Node, error) {
+	if rpcURL := os.Getenv("JUNO_TEST_RPC_URL"); strings.TrimSpace(rpcURL) != "" {
+		return connectExternal(ctx, rpcURL)
+	}
+	return startLocal(ctx, cfg)
+}
+
+// startLocal runs a local junocashd, ignoring JUNO_TEST_RPC_URL.
+func startLocal(ctx context.Context, cfg Config) (*Node, error) {
+	bin := cfg.JunocashdPath
+	if bin == "" {
+		p, err := exec.LookPath("junocashd")
+		if err != nil {
+			return nil, ErrJunocashdNotFound
+		}
+		bin = p
+	}
+
+	rpcUser := cfg.RPCUser
+	if rpcUser == "" {
+		rpcUser = "rpcuser"
+	}
+	rpcPass := cfg.RPCPassword
+	if rpcPass == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("junoregtest: rand: %w", err)
+		}
+		rpcPass = hex.EncodeToString(b)
+	}
+
+	rpcPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	p2pPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	datadir, err := os.MkdirTemp("", "junocashd-regtest-*")
+	if err != nil {
+		return nil, fmt.Errorf("junoregtest: mkdtemp: %w", err)
+	}
+
+	args := []string{
+		"-regtest",
+		"-server=1",
+		"-daemon=0",
+		"-printtoconsole=1",
+		fmt.Sprintf("-datadir=%s", datadir),
+		fmt.Sprintf("-rpcbind=%s", "127.0.0.1"),
+		fmt.Sprintf("-rpcallowip=%s", "127.0.0.1"),
+		fmt.Sprintf("-rpcport=%d", rpcPort),
+		fmt.Sprintf("-rpcuser=%s", rpcUser),
+		fmt.Sprintf("-rpcpassword=%s", rpcPass),
+		fmt.Sprintf("-port=%d", p2pPort),
+	}
+	if cfg.Listen {
+		args = append(args, "-listen=1", "-bind=127.0.0.1", "-discover=0", "-dnsseed=0")
+	} else {
+		args = append(args, "-listen=0")
+	}
+
+	n := &Node{
+		Datadir:     datadir,
+		RPCURL:      fmt.Sprintf("http://127.0.0.1:%d", rpcPort),
+		RPCPort:     rpcPort,
+		RPCUser:     rpcUser,
+		RPCPassword: rpcPass,
+		cliPath:     defaultCLIPath(cfg.JunocashCLI),
+		bin:         bin,
+		args:        append(args, cfg.Args...),
+	}
+	if cfg.Listen {
+		n.P2PAddr = fmt.Sprintf("127.0.0.1:%d", p2pPort)
+	}
+	if err := n.startProcess(ctx); err != nil {
+		_ = os.RemoveAll(datadir)
+		return nil, err
+	}
+	return n, nil
+}
+
+func connectExternal(ctx context.Context, rpcURL string) (*Node, error) {
+	parsed, err := url.Parse(rpcURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("junoregtest: invalid JUNO_TEST_RPC_URL: %q", rpcURL)
+	}
+	if parsed.Port() == "" {
+		return nil, fmt.Errorf("junoregtest: invalid JUNO_TEST_RPC_URL (missing port): %q", rpcURL)
+	}
+	rpcPort, err := strconv.Atoi(parsed.Port())
+	if err != nil {
+		return nil, fmt.Errorf("junoregtest: invalid JUNO_TEST_RPC_URL port: %q", parsed.Port())
+	}
+
+	rpcUser := strings.TrimSpace(os.Getenv("JUNO_TEST_RPC_USER"))
+	if rpcUser == "" {
+		rpcUser = "rpcuser"
+	}
+	rpcPass := strings.TrimSpace(os.Getenv("JUNO_TEST_RPC_PASS"))
+	if rpcPass == "" {
+		rpcPass = "rpcpass"
+	}
+
+	container := strings.TrimSpace(os.Getenv("JUNO_TEST_JUNOCASHD_CONTAINER"))
+	if container == "" {
+		return nil, errors.New("junoregtest: JUNO_TEST_JUNOCASHD_CONTAINER is required when JUNO_TEST_RPC_URL is set")
+	}
+	datadir := strings.TrimSpace(os.Getenv("JUNO_TEST_JUNOCASHD_DATADIR"))
+	if datadir == "" {
+		datadir = "/data"
+	}
+	internalRPCPort := 8232
+	if v := strings.TrimSpace(os.Getenv("JUNO_TEST_JUNOCASHD_RPC_PORT")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			internalRPCPort = n
+		}
+	}
+
+	n := &Node{
+		Datadir:       datadir,
+		RPCURL:        (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host}).String(),
+		RPCPort:       rpcPort,
+		RPCUser:       rpcUser,
+		RPCPassword:   rpcPass,
+		ContainerID:   container,
+		cliPath:       "junocash-cli",
+		dockerDatadir: datadir,
+		dockerRPCPort: internalRPCPort,
+	}
+	if err := n.waitForRPC(ctx, rpcReadyTimeout); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Client returns a junocashd.Client for the node.
+func (n *Node) Client(opts ...junocashd.Option) *junocashd.Client {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return junocashd.New(n.RPCURL, n.RPCUser, n.RPCPassword, opts...)
+}
+
+// Stop shuts the node down and removes its datadir and snapshots. A node attached through
+// JUNO_TEST_RPC_URL is left running. Stop is idempotent.
+func (n *Node) Stop(ctx context.Context) error {
+	var err error
+	n.stopOnce.Do(func() {
+		n.mu.Lock()
+		snapshots := n.snapshots
+		n.snapshots = nil
+		n.mu.Unlock()
+		for _, s := range snapshots {
+			_ = s.remove(context.Background())
+		}
+
+		switch {
+		case n.terminate != nil:
+			err = n.terminate(ctx)
+		case n.cmd != nil:
+			n.stopProcess(ctx)
+			_ = os.RemoveAll(n.Datadir)
+		}
+	})
+	return err
+}
+
+// CLICommand returns a junocash-cli command for the node with args appended, run through
+// docker exec for a node in Docker.
+func (n *Node) CLICommand(ctx context.Context, args ...string) *exec.Cmd {
+	if n.ContainerID != "" {
+		base := []string{
+			"exec",
+			n.ContainerID,
+			n.cliPath,
+			"-regtest",
+			"-datadir=" + n.dockerDatadir,
+			"-rpcuser=" + n.RPCUser,
+			"-rpcpassword=" + n.RPCPassword,
+			"-rpcport=" + fmt.Sprint(n.dockerRPCPort),
+		}
+		return exec.CommandContext(ctx, "docker", append(base, args...)...)
+	}
+
+	base := []string{
+		"-regtest",
+		"-datadir=" + n.Datadir,
+		"-rpcuser=" + n.RPCUser,
+		"-rpcpassword=" + n.RPCPassword,
+		"-rpcport=" + fmt.Sprint(n.RPCPort),
+	}
+	return exec.CommandContext(ctx, n.cliPath, append(base, args...)...)
+}
+
+// CLI runs junocash-cli with args and decodes its JSON output into out, if out is non-nil.
+// Output that is not JSON, such as a bare txid, can be read into a *string.
+func (n *Node) CLI(ctx context.Context, out any, args ...string) error {
+	b, err := n.CLICommand(ctx, args...).Output()
+	if err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) {
+			return fmt.Errorf("junoregtest: junocash-cli %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return fmt.Errorf("junoregtest: junocash-cli %s: %w", strings.Join(args, " "), err)
+	}
+	if out == nil {
+		return nil
+	}
+	if s, ok := out.(*string); ok {
+		*s = strings.TrimSpace(string(b))
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("junoregtest: junocash-cli %s: invalid json output: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// startProcess runs the local junocashd and waits for its RPC server.
+func (n *Node) startProcess(ctx context.Context) error {
+	cmd := exec.Command(n.bin, n.args...)
+	cmd.Dir = n.Datadir
+	logs := io.Discard
+	if os.Getenv("JUNO_TEST_LOG") != "" {
+		logs = os.Stdout
+	}
+	cmd.Stdout = logs
+	cmd.Stderr = logs
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("junoregtest: start junocashd: %w", err)
+	}
+	n.cmd = cmd
+
+	if err := n.waitForRPC(ctx, rpcReadyTimeout); err != nil {
+		n.stopProcess(context.Background())
+		return err
+	}
+	return nil
+}
+
+// stopProcess stops the local junocashd, gracefully unless ctx ends first.
+func (n *Node) stopProcess(ctx context.Context) {
+	if n.cmd == nil || n.cmd.Process == nil {
+		return
+	}
+	if runtime.GOOS == "windows" {
+		_ = n.cmd.Process.Kill()
+	} else {
+		_ = n.cmd.Process.Signal(syscall.SIGTERM)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = n.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		_ = n.cmd.Process.Kill()
+		<-done
+	}
+	n.cmd = nil
+}
+
+func (n *Node) waitForRPC(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cli := n.Client()
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		_, err := cli.GetBlockchainInfo(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("junoregtest: junocashd rpc not ready: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func freePort() (int, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("junoregtest: listen: %w", err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port, nil
+}
+
+func defaultCLIPath(cli string) string {
+	if strings.TrimSpace(cli) != "" {
+		return cli
+	}
+	return "junocash-cli"
+}
@@ -0,0 +1,201 @@
+package junoregtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Snapshot is a copy of a node's datadir, chain and wallet included, taken by Node.Snapshot.
+// It is removed when the node stops.
+type Snapshot struct {
+	// dir holds the copy of a local node, in the temporary directory root.
+	root, dir string
+	// volume holds the copy of a node in Docker.
+	volume string
+	image  string
+}
+
+// Snapshot stops the node, copies its datadir and starts it again. Pass the snapshot to Restore
+// to reset the node to this state, e.g. from each test of a suite that shares a funded node.
+//
+// For a node in Docker the copy is a Docker volume filled through a helper container that
+// mounts the node's /data volume, so the image must declare it (docker/junocashd does).
+func (n *Node) Snapshot(ctx context.Context) (*Snapshot, error) {
+	var s *Snapshot
+	err := n.restart(ctx, func() error {
+		var err error
+		if n.ContainerID != "" {
+			s, err = n.snapshotDocker(ctx)
+		} else {
+			s, err = n.snapshotLocal()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	n.snapshots = append(n.snapshots, s)
+	n.mu.Unlock()
+	return s, nil
+}
+
+// Restore stops the node, replaces its datadir with the snapshot and starts it again. A
+// snapshot can be restored any number of times.
+func (n *Node) Restore(ctx context.Context, s *Snapshot) error {
+	if s == nil {
+		return errors.New("junoregtest: nil snapshot")
+	}
+	return n.restart(ctx, func() error {
+		if n.ContainerID != "" {
+			if s.volume == "" {
+				return errors.New("junoregtest: snapshot of a local node")
+			}
+			return runDocker(ctx, "run", "--rm", "--volumes-from", n.ContainerID, "-v", s.volume+":/snap",
+				"--entrypoint", "sh", s.image, "-c", fmt.Sprintf("find %s -mindepth 1 -delete && cp -a /snap/. %s/", n.dockerDatadir, n.dockerDatadir))
+		}
+		if s.dir == "" {
+			return errors.New("junoregtest: snapshot of a node in Docker")
+		}
+		if err := os.RemoveAll(n.Datadir); err != nil {
+			return fmt.Errorf("junoregtest: clear datadir: %w", err)
+		}
+		if err := os.CopyFS(n.Datadir, os.DirFS(s.dir)); err != nil {
+			return fmt.Errorf("junoregtest: restore datadir: %w", err)
+		}
+		return nil
+	})
+}
+
+// restart stops the node, runs fn and starts the node again, even if fn failed.
+func (n *Node) restart(ctx context.Context, fn func() error) error {
+	switch {
+	case n.ContainerID != "":
+		if err := runDocker(ctx, "stop", n.ContainerID); err != nil {
+			return err
+		}
+		err := fn()
+		if serr := runDocker(ctx, "start", n.ContainerID); serr != nil {
+			return errors.Join(err, serr)
+		}
+		if serr := n.refreshDockerEndpoint(ctx); serr != nil {
+			return errors.Join(err, serr)
+		}
+		if serr := n.waitForRPC(ctx, rpcReadyTimeout); serr != nil {
+			return errors.Join(err, serr)
+		}
+		return err
+	case n.cmd != nil:
+		n.stopProcess(ctx)
+		err := fn()
+		return errors.Join(err, n.startProcess(ctx))
+	default:
+		return errors.New("junoregtest: node is not running")
+	}
+}
+
+func (n *Node) snapshotLocal() (*Snapshot, error) {
+	root, err := os.MkdirTemp("", "junocashd-snapshot-*")
+	if err != nil {
+		return nil, fmt.Errorf("junoregtest: mkdtemp: %w", err)
+	}
+	// CopyFS does not overwrite, so copy into a fresh subdirectory.
+	s := &Snapshot{root: root, dir: filepath.Join(root, "datadir")}
+	if err := os.CopyFS(s.dir, os.DirFS(n.Datadir)); err != nil {
+		_ = os.RemoveAll(root)
+		return nil, fmt.Errorf("junoregtest: copy datadir: %w", err)
+	}
+	return s, nil
+}
+
+func (n *Node) snapshotDocker(ctx context.Context) (*Snapshot, error) {
+	image, err := outputDocker(ctx, "inspect", "-f", "{{.Config.Image}}", n.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := outputDocker(ctx, "volume", "create")
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{volume: volume, image: image}
+	if err := runDocker(ctx, "run", "--rm", "--volumes-from", n.ContainerID, "-v", volume+":/snap",
+		"--entrypoint", "sh", image, "-c", fmt.Sprintf("cp -a %s/. /snap/", n.dockerDatadir)); err != nil {
+		_ = s.remove(context.Background())
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Snapshot) remove(ctx context.Context) error {
+	if s.volume != "" {
+		return runDocker(ctx, "volume", "rm", "-f", s.volume)
+	}
+	return os.RemoveAll(s.root)
+}
+
+// refreshDockerEndpoint points RPCURL at the host port currently published for the node's RPC
+// port, which changes when the container restarts, and P2PAddr at the container's address.
+func (n *Node) refreshDockerEndpoint(ctx context.Context) error {
+	out, err := outputDocker(ctx, "port", n.ContainerID, fmt.Sprintf("%d/tcp", n.dockerRPCPort))
+	if err != nil {
+		return err
+	}
+	first, _, _ := strings.Cut(out, "\n")
+	_, port, err := net.SplitHostPort(strings.TrimSpace(first))
+	if err != nil {
+		return fmt.Errorf("junoregtest: parse docker port %q: %w", first, err)
+	}
+	// Peers reach the node at its address on the Docker network, which can also change.
+	var ip string
+	if n.dockerP2PPort != 0 {
+		out, err := outputDocker(ctx, "inspect", "-f", "{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}", n.ContainerID)
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(out)
+		if len(fields) == 0 {
+			return fmt.Errorf("junoregtest: container %s has no network address", n.ContainerID)
+		}
+		ip = fields[0]
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	host := "127.0.0.1"
+	if n.RPCURL != "" {
+		if u, err := url.Parse(n.RPCURL); err == nil && u.Hostname() != "" {
+			host = u.Hostname()
+		}
+	}
+	n.RPCURL = "http://" + net.JoinHostPort(host, port)
+	n.RPCPort, _ = strconv.Atoi(port)
+	if n.dockerP2PPort != 0 {
+		n.P2PAddr = net.JoinHostPort(ip, strconv.Itoa(n.dockerP2PPort))
+	}
+	return nil
+}
+
+func runDocker(ctx context.Context, args ...string) error {
+	_, err := outputDocker(ctx, args...)
+	return err
+}
+
+func outputDocker(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) {
+			return "", fmt.Errorf("junoregtest: docker %s: %w: %s", args[0], err, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("junoregtest: docker %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
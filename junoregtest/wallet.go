@@ -0,0 +1,183 @@
+package junoregtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+const (
+	// CoinbaseMaturity is the number of confirmations a coinbase output needs to be spent.
+	CoinbaseMaturity = 100
+
+	pollInterval = 200 * time.Millisecond
+)
+
+// OrchardAccount is a wallet account of the node with an Orchard-only unified address.
+type OrchardAccount struct {
+	Account uint32
+	Address string
+	UFVK    string
+}
+
+// Generate mines count blocks to the node's wallet and returns their hashes.
+func (n *Node) Generate(ctx context.Context, count int) ([]string, error) {
+	var hashes []string
+	if err := n.Client().Call(ctx, "generate", []any{count}, &hashes); err != nil {
+		return nil, fmt.Errorf("junoregtest: generate: %w", err)
+	}
+	return hashes, nil
+}
+
+// GenerateToAddress mines count blocks whose coinbase pays addr and returns their hashes.
+func (n *Node) GenerateToAddress(ctx context.Context, count int, addr string) ([]string, error) {
+	var hashes []string
+	if err := n.Client().Call(ctx, "generatetoaddress", []any{count, addr}, &hashes); err != nil {
+		return nil, fmt.Errorf("junoregtest: generatetoaddress: %w", err)
+	}
+	return hashes, nil
+}
+
+// NewOrchardAccount creates a wallet account, derives its Orchard-only unified address and
+// exports its UFVK.
+func (n *Node) NewOrchardAccount(ctx context.Context) (OrchardAccount, error) {
+	cli := n.Client()
+	var acct struct {
+		Account uint32 `json:"account"`
+	}
+	if err := cli.Call(ctx, "z_getnewaccount", []any{}, &acct); err != nil {
+		return OrchardAccount{}, fmt.Errorf("junoregtest: z_getnewaccount: %w", err)
+	}
+	var addr struct {
+		Address string `json:"address"`
+	}
+	if err := cli.Call(ctx, "z_getaddressforaccount", []any{acct.Account, []string{"orchard"}}, &addr); err != nil {
+		return OrchardAccount{}, fmt.Errorf("junoregtest: z_getaddressforaccount: %w", err)
+	}
+	ufvk, err := n.ExportUFVK(ctx, addr.Address)
+	if err != nil {
+		return OrchardAccount{}, err
+	}
+	return OrchardAccount{Account: acct.Account, Address: addr.Address, UFVK: ufvk}, nil
+}
+
+// ExportUFVK returns the unified full viewing key of the account that owns the unified address
+// ua, e.g. to register it with juno-scan.
+func (n *Node) ExportUFVK(ctx context.Context, ua string) (string, error) {
+	var ufvk string
+	if err := n.Client().Call(ctx, "z_exportviewingkey", []any{ua}, &ufvk); err != nil {
+		return "", fmt.Errorf("junoregtest: z_exportviewingkey: %w", err)
+	}
+	if err := address.ValidateUFVK(ufvk); err != nil {
+		return "", fmt.Errorf("junoregtest: exported ufvk: %w", err)
+	}
+	return ufvk, nil
+}
+
+// Fund sends amount to addr from the wallet's mature coinbase outputs, mines a block and waits
+// for the transaction to confirm. It first mines enough blocks for a coinbase output to mature.
+// It returns the txid.
+func (n *Node) Fund(ctx context.Context, addr string, amount types.Zatoshi) (string, error) {
+	if !amount.Valid() || amount == 0 {
+		return "", fmt.Errorf("junoregtest: invalid amount %d", amount)
+	}
+	cli := n.Client()
+	height, err := cli.GetBlockCount(ctx)
+	if err != nil {
+		return "", fmt.Errorf("junoregtest: getblockcount: %w", err)
+	}
+	if height <= CoinbaseMaturity {
+		if _, err := n.Generate(ctx, int(CoinbaseMaturity+1-height)); err != nil {
+			return "", err
+		}
+	}
+
+	recipients := []map[string]any{{"address": addr, "amount": coins(amount)}}
+	var opid string
+	if err := cli.Call(ctx, "z_sendmany", []any{"ANY_TADDR", recipients, 1, nil, "AllowRevealedSenders"}, &opid); err != nil {
+		return "", fmt.Errorf("junoregtest: z_sendmany: %w", err)
+	}
+	txid, err := n.waitOperation(ctx, opid)
+	if err != nil {
+		return "", err
+	}
+	if _, err := n.Generate(ctx, 1); err != nil {
+		return "", err
+	}
+	if err := n.WaitForTx(ctx, txid, 1); err != nil {
+		return "", err
+	}
+	return txid, nil
+}
+
+// WaitForTx polls the node's wallet until the transaction txid has at least confirmations
+// confirmations. It does not mine.
+func (n *Node) WaitForTx(ctx context.Context, txid string, confirmations int64) error {
+	cli := n.Client()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		var tx struct {
+			Confirmations int64 `json:"confirmations"`
+		}
+		err := cli.Call(ctx, "gettransaction", []any{txid}, &tx)
+		if err == nil && tx.Confirmations >= confirmations {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("junoregtest: wait for %s: %w (last error: %v)", txid, ctx.Err(), err)
+			}
+			return fmt.Errorf("junoregtest: wait for %s: %w (%d of %d confirmations)", txid, ctx.Err(), tx.Confirmations, confirmations)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitOperation waits for an async wallet operation and returns the txid it produced.
+func (n *Node) waitOperation(ctx context.Context, opid string) (string, error) {
+	cli := n.Client()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		var ops []struct {
+			Status string `json:"status"`
+			Result struct {
+				TxID string `json:"txid"`
+			} `json:"result"`
+			Error *struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := cli.Call(ctx, "z_getoperationresult", []any{[]string{opid}}, &ops); err != nil {
+			return "", fmt.Errorf("junoregtest: z_getoperationresult: %w", err)
+		}
+		if len(ops) > 0 {
+			op := ops[0]
+			switch {
+			case op.Status == "success" && op.Result.TxID != "":
+				return op.Result.TxID, nil
+			case op.Error != nil:
+				return "", fmt.Errorf("junoregtest: operation %s %s: %d %s", opid, op.Status, op.Error.Code, op.Error.Message)
+			default:
+				return "", fmt.Errorf("junoregtest: operation %s %s", opid, op.Status)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("junoregtest: wait for operation %s: %w", opid, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// coins formats zat as a JUNO amount with 8 decimals, as the wallet RPCs expect.
+func coins(zat types.Zatoshi) json.Number {
+	return json.Number(fmt.Sprintf("%d.%08d", zat/types.ZatoshiPerJUNO, zat%types.ZatoshiPerJUNO))
+}
@@ -0,0 +1,105 @@
+package junoscan
+
+import (
+	"fmt"
+
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+// DefaultMinConfirmations is the confirmation depth used by Balances when the policy does not
+// set one.
+const DefaultMinConfirmations = 1
+
+// BalancePolicy configures how Balances classifies unspent notes.
+type BalancePolicy struct {
+	// MinConfirmations is the number of confirmations a note needs to be spendable.
+	// Zero means DefaultMinConfirmations.
+	MinConfirmations int64
+}
+
+// Balance is a breakdown of a wallet's incoming notes at a given tip height. Every note is
+// counted in exactly one bucket.
+type Balance struct {
+	TipHeight int64 `json:"tip_height"`
+
+	// Spendable holds unspent notes with at least MinConfirmations confirmations, including
+	// notes whose pending spend expired without being mined.
+	Spendable      types.Zatoshi `json:"spendable"`
+	SpendableNotes int           `json:"spendable_notes"`
+	// Awaiting holds unspent notes still below MinConfirmations (including unmined notes).
+	Awaiting      types.Zatoshi `json:"awaiting"`
+	AwaitingNotes int           `json:"awaiting_notes"`
+	// PendingSpent holds notes used by a broadcast transaction that is not yet mined and has
+	// not reached its expiry height.
+	PendingSpent      types.Zatoshi `json:"pending_spent"`
+	PendingSpentNotes int           `json:"pending_spent_notes"`
+	// Spent holds notes whose spend has been mined.
+	Spent      types.Zatoshi `json:"spent"`
+	SpentNotes int           `json:"spent_notes"`
+}
+
+// Total returns the value of all unspent notes (Spendable + Awaiting + PendingSpent).
+func (b Balance) Total() (types.Zatoshi, error) {
+	return types.SumZatoshi(b.Spendable, b.Awaiting, b.PendingSpent)
+}
+
+// Confirmations returns the number of confirmations of the note at tipHeight, or 0 if it is
+// not mined or above the tip.
+func (n WalletNote) Confirmations(tipHeight int64) int64 {
+	if n.Height <= 0 || n.Height > tipHeight {
+		return 0
+	}
+	return tipHeight - n.Height + 1
+}
+
+// PendingSpendActive reports whether the note has a pending spend that can still be mined.
+// A transaction with expiry height E is valid only in blocks up to E, so the pending spend is
+// dead once tipHeight reaches E.
+func (n WalletNote) PendingSpendActive(tipHeight int64) bool {
+	if n.PendingSpentTxID == nil || *n.PendingSpentTxID == "" {
+		return false
+	}
+	if n.PendingSpentExpiryHeight == nil || *n.PendingSpentExpiryHeight == 0 {
+		return true
+	}
+	return tipHeight < *n.PendingSpentExpiryHeight
+}
+
+// Balances classifies a wallet's notes at tipHeight. Outgoing notes are ignored.
+func Balances(notes []WalletNote, tipHeight int64, policy BalancePolicy) (Balance, error) {
+	minConf := policy.MinConfirmations
+	if minConf <= 0 {
+		minConf = DefaultMinConfirmations
+	}
+
+	b := Balance{TipHeight: tipHeight}
+	for i, n := range notes {
+		if n.Direction != "" && n.Direction != "incoming" {
+			continue
+		}
+		v, err := n.Value()
+		if err != nil {
+			return Balance{}, fmt.Errorf("junoscan: note %d: %w", i, err)
+		}
+
+		var bucket *types.Zatoshi
+		switch {
+		case n.SpentHeight != nil || (n.SpentTxID != nil && *n.SpentTxID != ""):
+			bucket = &b.Spent
+			b.SpentNotes++
+		case n.PendingSpendActive(tipHeight):
+			bucket = &b.PendingSpent
+			b.PendingSpentNotes++
+		case n.Confirmations(tipHeight) >= minConf:
+			bucket = &b.Spendable
+			b.SpendableNotes++
+		default:
+			bucket = &b.Awaiting
+			b.AwaitingNotes++
+		}
+		if *bucket, err = bucket.Add(v); err != nil {
+			return Balance{}, fmt.Errorf("junoscan: note %d: %w", i, err)
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,85 @@
+package junoscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestBalances(t *testing.T) {
+	notes := []junoscan.WalletNote{
+		{Height: 90, ValueZat: 1000}, // 11 confirmations at tip 100
+		{Height: 98, ValueZat: 2000}, // 3 confirmations
+		{Height: 0, ValueZat: 4000},  // unmined
+		{Height: 80, ValueZat: 8000, PendingSpentTxID: ptr("tx1"), PendingSpentExpiryHeight: ptr(int64(120))},
+		{Height: 80, ValueZat: 16000, PendingSpentTxID: ptr("tx2"), PendingSpentExpiryHeight: ptr(int64(100))}, // expired
+		{Height: 70, ValueZat: 32000, SpentHeight: ptr(int64(95)), SpentTxID: ptr("tx3")},
+		{Height: 70, ValueZat: 64000, Direction: "outgoing"},
+	}
+
+	tests := []struct {
+		name   string
+		policy junoscan.BalancePolicy
+		want   junoscan.Balance
+	}{
+		{
+			name:   "default policy",
+			policy: junoscan.BalancePolicy{},
+			want: junoscan.Balance{
+				TipHeight: 100,
+				Spendable: 19000, SpendableNotes: 3,
+				Awaiting: 4000, AwaitingNotes: 1,
+				PendingSpent: 8000, PendingSpentNotes: 1,
+				Spent: 32000, SpentNotes: 1,
+			},
+		},
+		{
+			name:   "ten confirmations",
+			policy: junoscan.BalancePolicy{MinConfirmations: 10},
+			want: junoscan.Balance{
+				TipHeight: 100,
+				Spendable: 17000, SpendableNotes: 2,
+				Awaiting: 6000, AwaitingNotes: 2,
+				PendingSpent: 8000, PendingSpentNotes: 1,
+				Spent: 32000, SpentNotes: 1,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := junoscan.Balances(notes, 100, tc.policy)
+			if err != nil {
+				t.Fatalf("Balances: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %+v\nwant %+v", got, tc.want)
+			}
+			total, err := got.Total()
+			if err != nil || total != tc.want.Spendable+tc.want.Awaiting+tc.want.PendingSpent {
+				t.Fatalf("Total=%d, %v", total, err)
+			}
+		})
+	}
+}
+
+func TestBalances_PendingSpendWithoutExpiry(t *testing.T) {
+	notes := []junoscan.WalletNote{{Height: 10, ValueZat: 500, PendingSpentTxID: ptr("tx")}}
+	got, err := junoscan.Balances(notes, 1_000_000, junoscan.BalancePolicy{})
+	if err != nil {
+		t.Fatalf("Balances: %v", err)
+	}
+	if got.PendingSpent != 500 {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestBalances_InvalidValue(t *testing.T) {
+	_, err := junoscan.Balances([]junoscan.WalletNote{{Height: 1, ValueZat: -5}}, 10, junoscan.BalancePolicy{})
+	if !errors.Is(err, types.ErrAmountOutOfRange) {
+		t.Fatalf("err=%v", err)
+	}
+}
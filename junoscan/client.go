@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
 )
 
 const (
@@ -22,6 +24,7 @@ type Client struct {
 	baseURL                  string
 	httpClient               *http.Client
 	orchardWitnessHTTPClient *http.Client
+	witnessCache             *witnessCache
 }
 
 type Option func(*Client)
@@ -45,8 +48,9 @@ func New(baseURL string, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		baseURL:    strings.TrimRight(baseURL, "/"),
-		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   &http.Client{Timeout: defaultHTTPTimeout},
+		witnessCache: newWitnessCache(),
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -84,6 +88,9 @@ func (c *Client) UpsertWallet(ctx context.Context, walletID, ufvk string) error
 	if walletID == "" || ufvk == "" {
 		return errors.New("junoscan: wallet_id and ufvk required")
 	}
+	if err := address.ValidateUFVK(ufvk); err != nil {
+		return fmt.Errorf("junoscan: invalid ufvk: %w", err)
+	}
 
 	var resp struct {
 		Status string `json:"status"`
@@ -127,16 +134,20 @@ func (c *Client) ListWalletEvents(ctx context.Context, walletID string, cursor i
 	return resp, nil
 }
 
+// ListWalletNotes returns every incoming note of the wallet, reading all pages.
 func (c *Client) ListWalletNotes(ctx context.Context, walletID string, onlyUnspent bool) ([]WalletNote, error) {
-	page, err := c.ListWalletNotesPage(ctx, walletID, ListWalletNotesOptions{
+	var notes []WalletNote
+	for n, err := range c.AllWalletNotes(ctx, walletID, ListWalletNotesOptions{
 		OnlyUnspent: onlyUnspent,
 		Direction:   "incoming",
 		Limit:       1000,
-	})
-	if err != nil {
-		return nil, err
+	}) {
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
 	}
-	return page.Notes, nil
+	return notes, nil
 }
 
 func (c *Client) ListWalletNotesPage(ctx context.Context, walletID string, opts ListWalletNotesOptions) (WalletNotesPage, error) {
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Abdullah1738/juno-sdk-go/address"
 	"github.com/Abdullah1738/juno-sdk-go/junoscan"
 	"github.com/Abdullah1738/juno-sdk-go/types"
 )
@@ -55,13 +56,14 @@ func TestClient_UpsertWalletAndList(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := c.UpsertWallet(ctx, "hot", "ufvk123"); err != nil {
+	ufvk := testUFVK(t)
+	if err := c.UpsertWallet(ctx, "hot", ufvk); err != nil {
 		t.Fatalf("UpsertWallet: %v", err)
 	}
 	if gotWalletID != "hot" {
 		t.Fatalf("wallet_id=%q", gotWalletID)
 	}
-	if gotUFVK != "ufvk123" {
+	if gotUFVK != ufvk {
 		t.Fatalf("ufvk=%q", gotUFVK)
 	}
 
@@ -74,6 +76,43 @@ func TestClient_UpsertWalletAndList(t *testing.T) {
 	}
 }
 
+func TestClient_UpsertWalletRejectsInvalidUFVK(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer srv.Close()
+
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := c.UpsertWallet(context.Background(), "hot", "ufvk123"); err == nil {
+		t.Fatalf("expected invalid ufvk error")
+	}
+	if calls != 0 {
+		t.Fatalf("invalid ufvk must not be sent to juno-scan")
+	}
+}
+
+func testUFVK(t *testing.T) string {
+	t.Helper()
+
+	fvk := make([]byte, address.OrchardFVKSize)
+	for i := range fvk {
+		fvk[i] = byte(i)
+	}
+	ufvk, err := (&address.UnifiedFullViewingKey{
+		Network: address.Regtest,
+		Items:   []address.Item{{Typecode: address.TypecodeOrchard, Data: fvk}},
+	}).Encode()
+	if err != nil {
+		t.Fatalf("encode ufvk: %v", err)
+	}
+	return ufvk
+}
+
 func TestClient_ListWalletEvents(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/v1/wallets/hot/events", func(w http.ResponseWriter, r *http.Request) {
@@ -125,6 +164,13 @@ func TestClient_ListWalletEvents(t *testing.T) {
 	if page.Events[0].Kind != types.WalletEventKindDepositEvent {
 		t.Fatalf("kind=%q", page.Events[0].Kind)
 	}
+	payload, err := page.Events[0].DecodePayload()
+	if err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	if p, ok := payload.(types.DepositEventPayload); !ok || p.TxID != "deadbeef" {
+		t.Fatalf("payload=%#v", payload)
+	}
 }
 
 func TestClient_HTTPErrorIncludesStatusCode(t *testing.T) {
@@ -285,3 +331,16 @@ func TestClient_OrchardWitnessUsesExtendedTimeoutBudget(t *testing.T) {
 		t.Fatalf("unexpected witness paths: %#v", got.Paths)
 	}
 }
+
+func TestTotalValue(t *testing.T) {
+	notes := []junoscan.WalletNote{{ValueZat: 5000}, {ValueZat: 7000}}
+	total, err := junoscan.TotalValue(notes)
+	if err != nil || total != 12000 {
+		t.Fatalf("TotalValue=%d, %v", total, err)
+	}
+
+	notes = append(notes, junoscan.WalletNote{ValueZat: -1})
+	if _, err := junoscan.TotalValue(notes); !errors.Is(err, types.ErrAmountOutOfRange) {
+		t.Fatalf("err=%v", err)
+	}
+}
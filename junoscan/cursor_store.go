@@ -0,0 +1,142 @@
+package junoscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CursorStore persists the last fully processed wallet event cursor per wallet.
+// Load returns 0 for a wallet that has no checkpoint yet.
+type CursorStore interface {
+	Load(ctx context.Context, walletID string) (int64, error)
+	Save(ctx context.Context, walletID string, cursor int64) error
+}
+
+// MemoryCursorStore is a CursorStore kept in process memory, useful for tests and
+// for consumers that are idempotent from cursor 0.
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]int64
+}
+
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string]int64{}}
+}
+
+func (s *MemoryCursorStore) Load(_ context.Context, walletID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[walletID], nil
+}
+
+func (s *MemoryCursorStore) Save(_ context.Context, walletID string, cursor int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cursors == nil {
+		s.cursors = map[string]int64{}
+	}
+	s.cursors[walletID] = cursor
+	return nil
+}
+
+// FileCursorStore is a CursorStore backed by a single JSON file. Every Save rewrites the file
+// atomically (write to a temp file, fsync, rename), so a crash leaves either the old or the new
+// checkpoint on disk, never a torn one.
+type FileCursorStore struct {
+	path string
+
+	mu      sync.Mutex
+	cursors map[string]int64
+}
+
+type cursorFile struct {
+	Cursors map[string]int64 `json:"cursors"`
+}
+
+// NewFileCursorStore opens the store at path, loading existing checkpoints if the file exists.
+func NewFileCursorStore(path string) (*FileCursorStore, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("junoscan: cursor file path required")
+	}
+	s := &FileCursorStore{path: path, cursors: map[string]int64{}}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("junoscan: read cursor file: %w", err)
+	}
+	var f cursorFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("junoscan: invalid cursor file %s: %w", path, err)
+	}
+	for k, v := range f.Cursors {
+		s.cursors[k] = v
+	}
+	return s, nil
+}
+
+func (s *FileCursorStore) Load(_ context.Context, walletID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[walletID], nil
+}
+
+func (s *FileCursorStore) Save(_ context.Context, walletID string, cursor int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := make(map[string]int64, len(s.cursors)+1)
+	for k, v := range s.cursors {
+		next[k] = v
+	}
+	next[walletID] = cursor
+
+	raw, err := json.MarshalIndent(cursorFile{Cursors: next}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("junoscan: marshal cursor file: %w", err)
+	}
+	if err := writeFileAtomic(s.path, append(raw, '\n')); err != nil {
+		return fmt.Errorf("junoscan: write cursor file: %w", err)
+	}
+	s.cursors = next
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return err
+	}
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package junoscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFollowerMinBackoff = 500 * time.Millisecond
+	defaultFollowerMaxBackoff = 30 * time.Second
+	defaultFollowerPageLimit  = 1000
+)
+
+// ErrEventCursorStalled is returned by EventFollower.Run when juno-scan returns a page of events
+// whose next_cursor does not advance past the requested cursor.
+var ErrEventCursorStalled = errors.New("junoscan: events cursor did not advance")
+
+// EventHandler processes one wallet event. Returning an error stops the follower without
+// advancing the cursor past the event's page.
+type EventHandler func(ctx context.Context, walletID string, e WalletEvent) error
+
+// EventFollower tails ListWalletEvents for a set of wallets and delivers events in order.
+//
+// The cursor of a wallet is persisted only after the handler has succeeded for every event of a
+// page, so delivery is at-least-once: after a crash the events of the unfinished page are
+// delivered again. When a poll round returns no events for any wallet the follower sleeps,
+// doubling the delay from the minimum up to the maximum; any event resets it. A wallet whose
+// fetch fails is retried on its own schedule with the same bounds, while the other wallets keep
+// being polled.
+type EventFollower struct {
+	client    *Client
+	store     CursorStore
+	handler   EventHandler
+	walletIDs []string
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	pageLimit  int
+	onError    func(walletID string, err error)
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+type FollowerOption func(*EventFollower)
+
+// WithFollowerBackoff sets the idle polling delay bounds.
+func WithFollowerBackoff(min, max time.Duration) FollowerOption {
+	return func(f *EventFollower) {
+		if min > 0 {
+			f.minBackoff = min
+		}
+		if max > 0 {
+			f.maxBackoff = max
+		}
+	}
+}
+
+// WithFollowerPageLimit sets the number of events requested per page (at most 1000).
+func WithFollowerPageLimit(n int) FollowerOption {
+	return func(f *EventFollower) {
+		if n > 0 {
+			f.pageLimit = n
+		}
+	}
+}
+
+// WithFollowerErrorHandler registers a callback for fetch errors. Transient fetch errors are
+// retried with per-wallet backoff; without a callback they are dropped silently. Terminal errors,
+// such as ErrEventCursorStalled, are reported too before Run returns them.
+func WithFollowerErrorHandler(fn func(walletID string, err error)) FollowerOption {
+	return func(f *EventFollower) {
+		f.onError = fn
+	}
+}
+
+func NewEventFollower(c *Client, store CursorStore, handler EventHandler, walletIDs []string, opts ...FollowerOption) (*EventFollower, error) {
+	if c == nil {
+		return nil, errors.New("junoscan: follower client required")
+	}
+	if store == nil {
+		return nil, errors.New("junoscan: follower cursor store required")
+	}
+	if handler == nil {
+		return nil, errors.New("junoscan: follower handler required")
+	}
+	ids := make([]string, 0, len(walletIDs))
+	seen := map[string]bool{}
+	for _, id := range walletIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, errors.New("junoscan: follower wallet_id required")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("junoscan: follower needs at least one wallet_id")
+	}
+
+	f := &EventFollower{
+		client:     c,
+		store:      store,
+		handler:    handler,
+		walletIDs:  ids,
+		minBackoff: defaultFollowerMinBackoff,
+		maxBackoff: defaultFollowerMaxBackoff,
+		pageLimit:  defaultFollowerPageLimit,
+		sleep:      sleepContext,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(f)
+		}
+	}
+	if f.maxBackoff < f.minBackoff {
+		f.maxBackoff = f.minBackoff
+	}
+	return f, nil
+}
+
+// Run follows the wallets until ctx is done or the handler or cursor store fails, or juno-scan
+// returns a cursor that does not advance. It returns ctx.Err() on cancellation.
+func (f *EventFollower) Run(ctx context.Context) error {
+	wallets := make(map[string]*followedWallet, len(f.walletIDs))
+	for _, id := range f.walletIDs {
+		c, err := f.store.Load(ctx, id)
+		if err != nil {
+			return fmt.Errorf("junoscan: load cursor for %s: %w", id, err)
+		}
+		wallets[id] = &followedWallet{cursor: c}
+	}
+
+	var backoff time.Duration
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		progressed := false
+		for _, id := range f.walletIDs {
+			w := wallets[id]
+			if time.Now().Before(w.retryAt) {
+				continue
+			}
+			n, err := f.poll(ctx, id, w)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				var fe *fetchError
+				if !errors.As(err, &fe) {
+					if errors.Is(err, ErrEventCursorStalled) && f.onError != nil {
+						f.onError(id, err)
+					}
+					return err
+				}
+				if f.onError != nil {
+					f.onError(id, fe.err)
+				}
+				w.backoff = nextBackoff(w.backoff, f.minBackoff, f.maxBackoff)
+				w.retryAt = time.Now().Add(w.backoff)
+				continue
+			}
+			w.backoff, w.retryAt = 0, time.Time{}
+			if n > 0 {
+				progressed = true
+			}
+		}
+
+		if progressed {
+			backoff = 0
+			continue
+		}
+		backoff = nextBackoff(backoff, f.minBackoff, f.maxBackoff)
+		delay := backoff
+		for _, w := range wallets {
+			if until := time.Until(w.retryAt); !w.retryAt.IsZero() && until < delay {
+				delay = max(until, 0)
+			}
+		}
+		if err := f.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// followedWallet is the polling state of one wallet in Run.
+type followedWallet struct {
+	cursor int64
+	// backoff is the current fetch error delay; the wallet is not polled before retryAt.
+	backoff time.Duration
+	retryAt time.Time
+}
+
+type fetchError struct{ err error }
+
+func (e *fetchError) Error() string { return e.err.Error() }
+
+// poll fetches and handles one page for walletID, returning the number of events delivered.
+func (f *EventFollower) poll(ctx context.Context, walletID string, w *followedWallet) (int, error) {
+	cursor := w.cursor
+	page, err := f.client.ListWalletEvents(ctx, walletID, cursor, f.pageLimit)
+	if err != nil {
+		return 0, &fetchError{err: err}
+	}
+	if len(page.Events) == 0 {
+		return 0, nil
+	}
+	if page.NextCursor <= cursor {
+		return 0, fmt.Errorf("%w: wallet %s returned next_cursor %d for cursor %d", ErrEventCursorStalled, walletID, page.NextCursor, cursor)
+	}
+
+	for _, e := range page.Events {
+		if err := f.handler(ctx, walletID, e); err != nil {
+			return 0, fmt.Errorf("junoscan: handle event %d for %s: %w", e.ID, walletID, err)
+		}
+	}
+	if err := f.store.Save(ctx, walletID, page.NextCursor); err != nil {
+		return 0, fmt.Errorf("junoscan: save cursor for %s: %w", walletID, err)
+	}
+	w.cursor = page.NextCursor
+	return len(page.Events), nil
+}
+
+func nextBackoff(cur, min, max time.Duration) time.Duration {
+	if cur <= 0 {
+		return min
+	}
+	cur *= 2
+	if cur > max {
+		return max
+	}
+	return cur
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,312 @@
+package junoscan_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+type fakeEvents struct {
+	mu       sync.Mutex
+	events   map[string][]int64
+	cursors  map[string][]int64
+	failNext atomic.Int32
+	// failing wallets always get an error; stall makes next_cursor echo the request cursor.
+	failing map[string]bool
+	stall   atomic.Bool
+}
+
+func newFakeEventsServer(t *testing.T, events map[string][]int64) (*fakeEvents, *junoscan.Client) {
+	t.Helper()
+	fe := &fakeEvents{events: events, cursors: map[string][]int64{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(parts) != 4 || parts[3] != "events" {
+			http.NotFound(w, r)
+			return
+		}
+		if fe.failNext.Load() > 0 {
+			fe.failNext.Add(-1)
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		wallet := parts[2]
+		cursor, _ := strconv.ParseInt(r.URL.Query().Get("cursor"), 10, 64)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		fe.mu.Lock()
+		fe.cursors[wallet] = append(fe.cursors[wallet], cursor)
+		if fe.failing[wallet] {
+			fe.mu.Unlock()
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var out []map[string]any
+		next := cursor
+		for _, id := range fe.events[wallet] {
+			if id <= cursor || len(out) >= limit {
+				continue
+			}
+			out = append(out, map[string]any{
+				"id":         id,
+				"kind":       string(types.WalletEventKindDepositEvent),
+				"height":     id,
+				"payload":    json.RawMessage(`{}`),
+				"created_at": time.Unix(1, 0).UTC(),
+			})
+			next = id
+		}
+		if fe.stall.Load() {
+			next = cursor
+		}
+		fe.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"events": out, "next_cursor": next})
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return fe, c
+}
+
+func TestEventFollower_DeliversInOrderAndCheckpoints(t *testing.T) {
+	_, c := newFakeEventsServer(t, map[string][]int64{
+		"hot":  {1, 2, 5, 6, 9},
+		"cold": {3, 4},
+	})
+	store := junoscan.NewMemoryCursorStore()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	got := map[string][]int64{}
+	total := 0
+	handler := func(_ context.Context, walletID string, e junoscan.WalletEvent) error {
+		got[walletID] = append(got[walletID], e.ID)
+		if total++; total == 7 {
+			cancel()
+		}
+		return nil
+	}
+	f, err := junoscan.NewEventFollower(c, store, handler, []string{"hot", "cold"},
+		junoscan.WithFollowerPageLimit(2),
+		junoscan.WithFollowerBackoff(time.Millisecond, 5*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewEventFollower: %v", err)
+	}
+	if err := f.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []int64{1, 2, 5, 6, 9}; !slices.Equal(got["hot"], want) {
+		t.Fatalf("hot=%v, want %v", got["hot"], want)
+	}
+	if want := []int64{3, 4}; !slices.Equal(got["cold"], want) {
+		t.Fatalf("cold=%v, want %v", got["cold"], want)
+	}
+	// The last page of hot ([9]) is checkpointed before the handler's cancel is observed.
+	if c, _ := store.Load(context.Background(), "hot"); c != 9 {
+		t.Fatalf("hot cursor=%d", c)
+	}
+	if c, _ := store.Load(context.Background(), "cold"); c != 4 {
+		t.Fatalf("cold cursor=%d", c)
+	}
+}
+
+func TestEventFollower_HandlerErrorDoesNotAdvanceCursor(t *testing.T) {
+	_, c := newFakeEventsServer(t, map[string][]int64{"hot": {1, 2, 3, 4}})
+	store := junoscan.NewMemoryCursorStore()
+	boom := errors.New("boom")
+
+	handler := func(_ context.Context, _ string, e junoscan.WalletEvent) error {
+		if e.ID == 4 {
+			return boom
+		}
+		return nil
+	}
+	f, err := junoscan.NewEventFollower(c, store, handler, []string{"hot"}, junoscan.WithFollowerPageLimit(2))
+	if err != nil {
+		t.Fatalf("NewEventFollower: %v", err)
+	}
+	if err := f.Run(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("Run: %v, want boom", err)
+	}
+	if cur, _ := store.Load(context.Background(), "hot"); cur != 2 {
+		t.Fatalf("cursor=%d, want 2", cur)
+	}
+}
+
+func TestEventFollower_ResumesFromStoreAndRetriesFetchErrors(t *testing.T) {
+	fe, c := newFakeEventsServer(t, map[string][]int64{"hot": {1, 2, 3}})
+	fe.failNext.Store(2)
+	store := junoscan.NewMemoryCursorStore()
+	_ = store.Save(context.Background(), "hot", 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var fetchErrors int
+	var ids []int64
+	handler := func(_ context.Context, _ string, e junoscan.WalletEvent) error {
+		ids = append(ids, e.ID)
+		cancel()
+		return nil
+	}
+	f, err := junoscan.NewEventFollower(c, store, handler, []string{"hot"},
+		junoscan.WithFollowerBackoff(time.Millisecond, 2*time.Millisecond),
+		junoscan.WithFollowerErrorHandler(func(string, error) { fetchErrors++ }),
+	)
+	if err != nil {
+		t.Fatalf("NewEventFollower: %v", err)
+	}
+	if err := f.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v", err)
+	}
+	if fetchErrors != 2 {
+		t.Fatalf("fetchErrors=%d, want 2", fetchErrors)
+	}
+	if !slices.Equal(ids, []int64{3}) {
+		t.Fatalf("ids=%v, want [3]", ids)
+	}
+}
+
+func TestEventFollower_StalledCursorIsTerminal(t *testing.T) {
+	fe, c := newFakeEventsServer(t, map[string][]int64{"hot": {1, 2}})
+	fe.stall.Store(true)
+	store := junoscan.NewMemoryCursorStore()
+
+	var reported []error
+	handler := func(context.Context, string, junoscan.WalletEvent) error { return nil }
+	f, err := junoscan.NewEventFollower(c, store, handler, []string{"hot"},
+		junoscan.WithFollowerErrorHandler(func(_ string, err error) { reported = append(reported, err) }),
+	)
+	if err != nil {
+		t.Fatalf("NewEventFollower: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := f.Run(ctx); !errors.Is(err, junoscan.ErrEventCursorStalled) {
+		t.Fatalf("Run: %v, want ErrEventCursorStalled", err)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], junoscan.ErrEventCursorStalled) {
+		t.Fatalf("reported=%v", reported)
+	}
+	if cur, _ := store.Load(context.Background(), "hot"); cur != 0 {
+		t.Fatalf("cursor=%d, want 0", cur)
+	}
+}
+
+func TestEventFollower_BacksOffFailingWalletOnly(t *testing.T) {
+	fe, c := newFakeEventsServer(t, map[string][]int64{"hot": {1, 2, 3, 4, 5, 6}})
+	fe.failing = map[string]bool{"bad": true}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ids []int64
+	handler := func(_ context.Context, _ string, e junoscan.WalletEvent) error {
+		if ids = append(ids, e.ID); len(ids) == 6 {
+			cancel()
+		}
+		return nil
+	}
+	f, err := junoscan.NewEventFollower(c, junoscan.NewMemoryCursorStore(), handler, []string{"bad", "hot"},
+		junoscan.WithFollowerPageLimit(1),
+		junoscan.WithFollowerBackoff(time.Second, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("NewEventFollower: %v", err)
+	}
+	if err := f.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(ids) != 6 {
+		t.Fatalf("ids=%v", ids)
+	}
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if n := len(fe.cursors["bad"]); n != 1 {
+		t.Fatalf("failing wallet polled %d times, want 1 before its backoff elapses", n)
+	}
+}
+
+func TestNewEventFollower_Validation(t *testing.T) {
+	c, err := junoscan.New("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	h := func(context.Context, string, junoscan.WalletEvent) error { return nil }
+	store := junoscan.NewMemoryCursorStore()
+	if _, err := junoscan.NewEventFollower(c, store, h, nil); err == nil {
+		t.Fatalf("expected error for no wallets")
+	}
+	if _, err := junoscan.NewEventFollower(c, nil, h, []string{"hot"}); err == nil {
+		t.Fatalf("expected error for nil store")
+	}
+	if _, err := junoscan.NewEventFollower(c, store, nil, []string{"hot"}); err == nil {
+		t.Fatalf("expected error for nil handler")
+	}
+}
+
+func TestFileCursorStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cursors.json")
+	ctx := context.Background()
+
+	s, err := junoscan.NewFileCursorStore(path)
+	if err != nil {
+		t.Fatalf("NewFileCursorStore: %v", err)
+	}
+	if cur, err := s.Load(ctx, "hot"); err != nil || cur != 0 {
+		t.Fatalf("Load(empty)=%d, %v", cur, err)
+	}
+	if err := s.Save(ctx, "hot", 42); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Save(ctx, "cold", 7); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reopened, err := junoscan.NewFileCursorStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if cur, _ := reopened.Load(ctx, "hot"); cur != 42 {
+		t.Fatalf("hot=%d", cur)
+	}
+	if cur, _ := reopened.Load(ctx, "cold"); cur != 7 {
+		t.Fatalf("cold=%d", cur)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("leftover files: %v", entries)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := junoscan.NewFileCursorStore(path); err == nil {
+		t.Fatalf("expected error for corrupt file")
+	}
+}
@@ -0,0 +1,83 @@
+package junoscan
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// walletEventsPageLimit is the page size used by WalletEventsFrom (the server maximum).
+const walletEventsPageLimit = 1000
+
+// AllWalletNotes yields every note matching opts, following NextCursor across pages starting
+// at opts.Cursor. Iteration stops at the first error, which is yielded with a zero WalletNote,
+// or when ctx is done.
+func (c *Client) AllWalletNotes(ctx context.Context, walletID string, opts ListWalletNotesOptions) iter.Seq2[WalletNote, error] {
+	return func(yield func(WalletNote, error) bool) {
+		seen := map[string]bool{}
+		for {
+			if err := contextErr(ctx); err != nil {
+				yield(WalletNote{}, err)
+				return
+			}
+			page, err := c.ListWalletNotesPage(ctx, walletID, opts)
+			if err != nil {
+				yield(WalletNote{}, err)
+				return
+			}
+			for _, n := range page.Notes {
+				if !yield(n, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" || len(page.Notes) == 0 {
+				return
+			}
+			if seen[page.NextCursor] {
+				yield(WalletNote{}, fmt.Errorf("junoscan: notes cursor %q did not advance", page.NextCursor))
+				return
+			}
+			seen[page.NextCursor] = true
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
+
+// WalletEventsFrom yields the wallet's events after cursor, following NextCursor until a page
+// comes back empty, i.e. until the iterator has caught up with the scanner. Iteration stops at
+// the first error, which is yielded with a zero WalletEvent, or when ctx is done.
+func (c *Client) WalletEventsFrom(ctx context.Context, walletID string, cursor int64) iter.Seq2[WalletEvent, error] {
+	return func(yield func(WalletEvent, error) bool) {
+		for {
+			if err := contextErr(ctx); err != nil {
+				yield(WalletEvent{}, err)
+				return
+			}
+			page, err := c.ListWalletEvents(ctx, walletID, cursor, walletEventsPageLimit)
+			if err != nil {
+				yield(WalletEvent{}, err)
+				return
+			}
+			for _, e := range page.Events {
+				if !yield(e, nil) {
+					return
+				}
+			}
+			if len(page.Events) == 0 {
+				return
+			}
+			if page.NextCursor <= cursor {
+				yield(WalletEvent{}, fmt.Errorf("junoscan: events cursor did not advance past %d", cursor))
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
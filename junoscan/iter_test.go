@@ -0,0 +1,182 @@
+package junoscan_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func newNotesServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/wallets/hot/notes", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		start := 0
+		if c := q.Get("cursor"); c != "" {
+			start, _ = strconv.Atoi(c)
+		}
+		end := min(start+limit, total)
+		notes := []map[string]any{}
+		for i := start; i < end; i++ {
+			notes = append(notes, map[string]any{
+				"txid":         fmt.Sprintf("%064x", i),
+				"action_index": 0,
+				"height":       i,
+				"value_zat":    1,
+				"created_at":   time.Unix(1, 0).UTC(),
+			})
+		}
+		resp := map[string]any{"notes": notes}
+		if end < total {
+			resp["next_cursor"] = strconv.Itoa(end)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_ListWalletNotesReadsAllPages(t *testing.T) {
+	srv := newNotesServer(t, 2500)
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	notes, err := c.ListWalletNotes(context.Background(), "hot", true)
+	if err != nil {
+		t.Fatalf("ListWalletNotes: %v", err)
+	}
+	if len(notes) != 2500 {
+		t.Fatalf("notes=%d, want 2500", len(notes))
+	}
+	if notes[2499].Height != 2499 {
+		t.Fatalf("last height=%d", notes[2499].Height)
+	}
+}
+
+func TestClient_AllWalletNotesStopsEarly(t *testing.T) {
+	srv := newNotesServer(t, 50)
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var n int
+	for _, err := range c.AllWalletNotes(context.Background(), "hot", junoscan.ListWalletNotesOptions{Limit: 10}) {
+		if err != nil {
+			t.Fatalf("AllWalletNotes: %v", err)
+		}
+		if n++; n == 15 {
+			break
+		}
+	}
+	if n != 15 {
+		t.Fatalf("n=%d", n)
+	}
+}
+
+func TestClient_AllWalletNotesSurfacesErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/wallets/hot/notes", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") != "" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"notes":       []map[string]any{{"txid": "aa", "height": 1, "value_zat": 1}},
+			"next_cursor": "1",
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.ListWalletNotes(context.Background(), "hot", false); err == nil {
+		t.Fatalf("expected error")
+	} else {
+		var he *junoscan.HTTPError
+		if !errors.As(err, &he) || he.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("err=%v", err)
+		}
+	}
+}
+
+func TestClient_WalletEventsFrom(t *testing.T) {
+	const total = 2300
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/wallets/hot/events", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cursor, _ := strconv.ParseInt(r.URL.Query().Get("cursor"), 10, 64)
+		limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+		events := []map[string]any{}
+		next := cursor
+		for id := cursor + 1; id <= total && id <= cursor+limit; id++ {
+			events = append(events, map[string]any{
+				"id":         id,
+				"kind":       string(types.WalletEventKindDepositEvent),
+				"height":     id,
+				"payload":    json.RawMessage(`{}`),
+				"created_at": time.Unix(1, 0).UTC(),
+			})
+			next = id
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"events": events, "next_cursor": next})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	want := int64(100)
+	for e, err := range c.WalletEventsFrom(context.Background(), "hot", 99) {
+		if err != nil {
+			t.Fatalf("WalletEventsFrom: %v", err)
+		}
+		if e.ID != want {
+			t.Fatalf("id=%d, want %d", e.ID, want)
+		}
+		want++
+	}
+	if want != total+1 {
+		t.Fatalf("stopped at %d", want)
+	}
+	if requests != 4 {
+		t.Fatalf("requests=%d, want 4", requests)
+	}
+}
+
+func TestClient_WalletEventsFromContextCanceled(t *testing.T) {
+	c, err := junoscan.New("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var got error
+	for _, err := range c.WalletEventsFrom(ctx, "hot", 0) {
+		got = err
+	}
+	if !errors.Is(got, context.Canceled) {
+		t.Fatalf("err=%v, want context.Canceled", got)
+	}
+}
@@ -0,0 +1,226 @@
+package junoscantest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+)
+
+const maxPageLimit = 1000
+
+func (s *Server) withFailures(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		s.mu.Lock()
+		queue := s.failures[pattern]
+		var f *failure
+		if len(queue) > 0 {
+			f = &queue[0]
+			s.failures[pattern] = queue[1:]
+		}
+		s.mu.Unlock()
+		if f != nil {
+			http.Error(w, f.body, f.status)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	resp := junoscan.HealthResponse{Status: "ok", ScannedHeight: s.scannedHeight, ScannedHash: s.scannedHash}
+	s.mu.Unlock()
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleListWallets(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	wallets := s.sortedWallets()
+	s.mu.Unlock()
+	writeJSON(w, map[string]any{"wallets": wallets})
+}
+
+func (s *Server) handleUpsertWallet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		WalletID string `json:"wallet_id"`
+		UFVK     string `json:"ufvk"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	walletID, ufvk := strings.TrimSpace(req.WalletID), strings.TrimSpace(req.UFVK)
+	if walletID == "" || ufvk == "" {
+		writeError(w, http.StatusBadRequest, "wallet_id and ufvk required")
+		return
+	}
+	if err := address.ValidateUFVK(ufvk); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid ufvk")
+		return
+	}
+	s.AddWallet(walletID, ufvk)
+	writeJSON(w, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	cursor, err := intParam(q.Get("cursor"), 0)
+	if err != nil || cursor < 0 {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	limit, err := limitParam(q.Get("limit"), 100)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wl, ok := s.wallets[r.PathValue("wallet_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "wallet not found")
+		return
+	}
+	page := junoscan.WalletEventsPage{Events: []junoscan.WalletEvent{}, NextCursor: cursor}
+	for _, e := range wl.events {
+		if len(page.Events) == limit {
+			break
+		}
+		if e.ID > cursor {
+			page.Events = append(page.Events, e)
+			page.NextCursor = e.ID
+		}
+	}
+	writeJSON(w, page)
+}
+
+func (s *Server) handleNotes(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	includeSpent := q.Get("spent") == "true"
+	direction := q.Get("direction")
+	minValue, err := intParam(q.Get("min_value_zat"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid min_value_zat")
+		return
+	}
+	limit, err := limitParam(q.Get("limit"), maxPageLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	after, err := intParam(q.Get("cursor"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wl, ok := s.wallets[r.PathValue("wallet_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "wallet not found")
+		return
+	}
+	page := junoscan.WalletNotesPage{Notes: []junoscan.WalletNote{}}
+	var last int64
+	for _, n := range wl.notes {
+		if n.seq <= after ||
+			(!includeSpent && n.SpentHeight != nil) ||
+			(direction != "" && n.Direction != direction) ||
+			n.ValueZat < minValue {
+			continue
+		}
+		if len(page.Notes) == limit {
+			// More notes match: resume after the last one served.
+			page.NextCursor = strconv.FormatInt(last, 10)
+			break
+		}
+		page.Notes = append(page.Notes, n.WalletNote)
+		last = n.seq
+	}
+	writeJSON(w, page)
+}
+
+func (s *Server) handleWitness(w http.ResponseWriter, r *http.Request) {
+	var req junoscan.WitnessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if len(req.Positions) == 0 {
+		writeError(w, http.StatusBadRequest, "positions required")
+		return
+	}
+
+	s.mu.Lock()
+	if s.scannedHeight == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusServiceUnavailable, "not scanned yet")
+		return
+	}
+	anchor := *s.scannedHeight
+	if req.AnchorHeight != nil {
+		if *req.AnchorHeight < 0 || *req.AnchorHeight > anchor {
+			s.mu.Unlock()
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("anchor_height %d beyond scanned height %d", *req.AnchorHeight, anchor))
+			return
+		}
+		anchor = *req.AnchorHeight
+	}
+	size := 0
+	for size < len(s.leaves) && s.leaves[size].height <= anchor {
+		size++
+	}
+	leaves := s.leaves[:size]
+	s.mu.Unlock()
+
+	for _, p := range req.Positions {
+		if int(p) >= size {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("position %d not in the tree at height %d", p, anchor))
+			return
+		}
+	}
+	root, paths, err := witness(leaves, req.Positions)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resp := junoscan.OrchardWitnessResponse{Status: "ok", AnchorHeight: anchor, Root: root}
+	for i, p := range req.Positions {
+		resp.Paths = append(resp.Paths, junoscan.OrchardWitnessPath{Position: p, AuthPath: paths[i]})
+	}
+	writeJSON(w, resp)
+}
+
+func intParam(s string, def int64) (int64, error) {
+	if s == "" {
+		return def, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func limitParam(s string, def int) (int, error) {
+	v, err := intParam(s, int64(def))
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid limit %q", s)
+	}
+	return int(min(v, maxPageLimit)), nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,346 @@
+// Package junoscantest provides an in-memory juno-scan server implementing the /v1 REST API
+// used by junoscan.Client, for tests.
+//
+// State is seeded and mutated programmatically: tests register wallets, add notes (each incoming
+// note appends its commitment to an Orchard tree, so /v1/orchard/witness serves paths that verify
+// with the orchard package), emit typed wallet events and mark notes pending-spent or spent.
+// Event ids are global and increasing, as in juno-scan: a page holds the events after the
+// requested cursor and next_cursor is the id of its last event, or the cursor itself when the
+// page is empty.
+//
+// The server serves these routes, which are also the keys of FailNext:
+//
+//	GET  /v1/health
+//	GET  /v1/wallets
+//	POST /v1/wallets
+//	GET  /v1/wallets/{wallet_id}/events
+//	GET  /v1/wallets/{wallet_id}/notes
+//	POST /v1/orchard/witness
+package junoscantest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+// ErrNotFound is returned when a wallet or note does not exist.
+var ErrNotFound = errors.New("junoscantest: not found")
+
+// Server is an in-memory juno-scan. Its methods are safe for concurrent use with HTTP traffic.
+type Server struct {
+	// URL is the base URL, for junoscan.New.
+	URL string
+
+	srv *httptest.Server
+	now func() time.Time
+
+	mu            sync.Mutex
+	scannedHeight *int64
+	scannedHash   *string
+	wallets       map[string]*wallet
+	leaves        []leaf
+	lastEventID   int64
+	noteSeq       int64
+	failures      map[string][]failure
+}
+
+type wallet struct {
+	junoscan.Wallet
+	ufvk   string
+	events []junoscan.WalletEvent
+	notes  []*note
+}
+
+type note struct {
+	seq int64
+	junoscan.WalletNote
+}
+
+type failure struct {
+	status int
+	body   string
+}
+
+type Option func(*Server)
+
+// WithClock sets the source of created_at and pending_spent_at timestamps. The default is
+// time.Now in UTC.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+// NewServer starts an empty server that has not scanned any block. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:      func() time.Time { return time.Now().UTC() },
+		wallets:  map[string]*wallet{},
+		failures: map[string][]failure{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", s.handleHealth)
+	mux.HandleFunc("GET /v1/wallets", s.handleListWallets)
+	mux.HandleFunc("POST /v1/wallets", s.handleUpsertWallet)
+	mux.HandleFunc("GET /v1/wallets/{wallet_id}/events", s.handleEvents)
+	mux.HandleFunc("GET /v1/wallets/{wallet_id}/notes", s.handleNotes)
+	mux.HandleFunc("POST /v1/orchard/witness", s.handleWitness)
+	s.srv = httptest.NewServer(s.withFailures(mux))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() { s.srv.Close() }
+
+// Client returns a junoscan.Client for the server.
+func (s *Server) Client(opts ...junoscan.Option) *junoscan.Client {
+	c, err := junoscan.New(s.URL, opts...)
+	if err != nil {
+		panic(err) // the httptest URL is always valid
+	}
+	return c
+}
+
+// SetScanned sets the scanned tip reported by /v1/health, which is also the default anchor
+// height of /v1/orchard/witness.
+func (s *Server) SetScanned(height int64, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scannedHeight, s.scannedHash = &height, &hash
+}
+
+// AddWallet registers a wallet, as POST /v1/wallets does. Registering it again re-enables it.
+func (s *Server) AddWallet(walletID, ufvk string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upsertWallet(walletID, ufvk)
+}
+
+// DisableWallet sets the wallet's disabled_at.
+func (s *Server) DisableWallet(walletID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.wallets[walletID]
+	if !ok {
+		return fmt.Errorf("%w: wallet %q", ErrNotFound, walletID)
+	}
+	at := s.now()
+	w.DisabledAt = &at
+	return nil
+}
+
+// AppendCommitments appends note commitments mined at height to the Orchard tree and returns the
+// position of the first. Heights must not decrease. Use it for outputs that belong to no wallet,
+// or to control the commitment of a note that is then added with its Position set.
+func (s *Server) AppendCommitments(height int64, cmx ...[32]byte) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appendLeaves(height, cmx...)
+}
+
+// TreeSize returns the number of commitments in the Orchard tree.
+func (s *Server) TreeSize() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return uint32(len(s.leaves))
+}
+
+// Commitment returns the commitment at position.
+func (s *Server) Commitment(position uint32) ([32]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if int(position) >= len(s.leaves) {
+		return [32]byte{}, false
+	}
+	return s.leaves[position].cmx, true
+}
+
+// AddNote adds a note to the wallet and returns it as the notes endpoint will serve it.
+//
+// Direction defaults to "incoming", CreatedAt to the clock and NoteNullifier to a value derived
+// from the txid and action index. An incoming note without a Position gets a new leaf of the
+// Orchard tree at its height, with a commitment derived the same way (see Commitment); a note
+// with a Position must refer to an existing leaf. TxID and ActionIndex identify the note.
+func (s *Server) AddNote(walletID string, n junoscan.WalletNote) (junoscan.WalletNote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.wallets[walletID]
+	if !ok {
+		return junoscan.WalletNote{}, fmt.Errorf("%w: wallet %q", ErrNotFound, walletID)
+	}
+	if n.TxID == "" {
+		return junoscan.WalletNote{}, errors.New("junoscantest: note txid required")
+	}
+	if w.find(n.TxID, n.ActionIndex) != nil {
+		return junoscan.WalletNote{}, fmt.Errorf("junoscantest: note %s:%d already exists", n.TxID, n.ActionIndex)
+	}
+
+	if n.Direction == "" {
+		n.Direction = "incoming"
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = s.now()
+	}
+	if n.NoteNullifier == "" {
+		nf := derivedCMX("nf:"+n.TxID, n.ActionIndex)
+		n.NoteNullifier = hex.EncodeToString(nf[:])
+	}
+	switch {
+	case n.Position != nil:
+		if *n.Position < 0 || *n.Position >= int64(len(s.leaves)) {
+			return junoscan.WalletNote{}, fmt.Errorf("junoscantest: note position %d not in the tree of size %d", *n.Position, len(s.leaves))
+		}
+	case n.Direction == "incoming":
+		pos, err := s.appendLeaves(n.Height, derivedCMX(n.TxID, n.ActionIndex))
+		if err != nil {
+			return junoscan.WalletNote{}, err
+		}
+		p := int64(pos)
+		n.Position = &p
+	}
+
+	s.noteSeq++
+	w.notes = append(w.notes, &note{seq: s.noteSeq, WalletNote: n})
+	return n, nil
+}
+
+// MarkPendingSpent records that the note is used by spendTxID, a broadcast transaction that
+// expires after expiryHeight.
+func (s *Server) MarkPendingSpent(walletID, txid string, actionIndex int32, spendTxID string, expiryHeight int64) error {
+	return s.updateNote(walletID, txid, actionIndex, func(n *note) {
+		at := s.now()
+		n.PendingSpentTxID, n.PendingSpentAt, n.PendingSpentExpiryHeight = &spendTxID, &at, &expiryHeight
+	})
+}
+
+// MarkSpent records that the note was spent by spendTxID, mined at height. It clears any pending
+// spend.
+func (s *Server) MarkSpent(walletID, txid string, actionIndex int32, spendTxID string, height int64) error {
+	return s.updateNote(walletID, txid, actionIndex, func(n *note) {
+		n.SpentTxID, n.SpentHeight = &spendTxID, &height
+		n.PendingSpentTxID, n.PendingSpentAt, n.PendingSpentExpiryHeight = nil, nil, nil
+	})
+}
+
+// MarkUnspent clears the note's spend and pending spend, as after a reorg or an expiry.
+func (s *Server) MarkUnspent(walletID, txid string, actionIndex int32) error {
+	return s.updateNote(walletID, txid, actionIndex, func(n *note) {
+		n.SpentTxID, n.SpentHeight = nil, nil
+		n.PendingSpentTxID, n.PendingSpentAt, n.PendingSpentExpiryHeight = nil, nil, nil
+	})
+}
+
+// EmitEvent appends an event of kind to the wallet and returns it. The payload is marshalled to
+// JSON and must be of the payload type registered for kind (see types.DecodePayload); a
+// json.RawMessage payload is sent as is, which allows events of unknown kinds.
+func (s *Server) EmitEvent(walletID string, kind types.WalletEventKind, height int64, payload any) (junoscan.WalletEvent, error) {
+	if payload == nil {
+		return junoscan.WalletEvent{}, fmt.Errorf("junoscantest: %s payload required", kind)
+	}
+	raw, ok := payload.(json.RawMessage)
+	if !ok {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return junoscan.WalletEvent{}, fmt.Errorf("junoscantest: marshal %s payload: %w", kind, err)
+		}
+		raw = b
+		v, err := types.DecodePayload(kind, raw)
+		if err != nil {
+			return junoscan.WalletEvent{}, fmt.Errorf("junoscantest: %w", err)
+		}
+		if want, got := reflect.TypeOf(v), reflect.Indirect(reflect.ValueOf(payload)).Type(); got != want {
+			return junoscan.WalletEvent{}, fmt.Errorf("junoscantest: %s payload must be %s, not %s", kind, want, got)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.wallets[walletID]
+	if !ok {
+		return junoscan.WalletEvent{}, fmt.Errorf("%w: wallet %q", ErrNotFound, walletID)
+	}
+	s.lastEventID++
+	e := junoscan.WalletEvent{ID: s.lastEventID, Kind: kind, Height: height, Payload: raw, CreatedAt: s.now()}
+	w.events = append(w.events, e)
+	return e, nil
+}
+
+// FailNext makes the next request to route fail with the HTTP status and body. route is one of
+// the patterns listed in the package documentation. Failures queue up per route.
+func (s *Server) FailNext(route string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[route] = append(s.failures[route], failure{status: status, body: body})
+}
+
+func (s *Server) upsertWallet(walletID, ufvk string) {
+	if w, ok := s.wallets[walletID]; ok {
+		w.ufvk, w.DisabledAt = ufvk, nil
+		return
+	}
+	s.wallets[walletID] = &wallet{Wallet: junoscan.Wallet{WalletID: walletID, CreatedAt: s.now()}, ufvk: ufvk}
+}
+
+func (s *Server) appendLeaves(height int64, cmx ...[32]byte) (uint32, error) {
+	if n := len(s.leaves); n > 0 && height < s.leaves[n-1].height {
+		return 0, fmt.Errorf("junoscantest: commitment height %d below tree height %d", height, s.leaves[n-1].height)
+	}
+	pos := uint32(len(s.leaves))
+	for _, c := range cmx {
+		s.leaves = append(s.leaves, leaf{cmx: c, height: height})
+	}
+	return pos, nil
+}
+
+func (s *Server) updateNote(walletID, txid string, actionIndex int32, fn func(*note)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.wallets[walletID]
+	if !ok {
+		return fmt.Errorf("%w: wallet %q", ErrNotFound, walletID)
+	}
+	n := w.find(txid, actionIndex)
+	if n == nil {
+		return fmt.Errorf("%w: note %s:%d", ErrNotFound, txid, actionIndex)
+	}
+	fn(n)
+	return nil
+}
+
+func (w *wallet) find(txid string, actionIndex int32) *note {
+	for _, n := range w.notes {
+		if n.TxID == txid && n.ActionIndex == actionIndex {
+			return n
+		}
+	}
+	return nil
+}
+
+func (s *Server) sortedWallets() []junoscan.Wallet {
+	out := make([]junoscan.Wallet, 0, len(s.wallets))
+	for _, w := range s.wallets {
+		out = append(out, w.Wallet)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].WalletID < out[j].WalletID })
+	return out
+}
@@ -0,0 +1,298 @@
+package junoscantest_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan/junoscantest"
+	"github.com/Abdullah1738/juno-sdk-go/orchard"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func testUFVK(t *testing.T) string {
+	t.Helper()
+
+	fvk := make([]byte, address.OrchardFVKSize)
+	for i := range fvk {
+		fvk[i] = byte(i)
+	}
+	ufvk, err := (&address.UnifiedFullViewingKey{
+		Network: address.Regtest,
+		Items:   []address.Item{{Typecode: address.TypecodeOrchard, Data: fvk}},
+	}).Encode()
+	if err != nil {
+		t.Fatalf("encode ufvk: %v", err)
+	}
+	return ufvk
+}
+
+func newServer(t *testing.T) (*junoscantest.Server, *junoscan.Client) {
+	t.Helper()
+	clock := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	srv := junoscantest.NewServer(junoscantest.WithClock(func() time.Time { return clock }))
+	t.Cleanup(srv.Close)
+	return srv, srv.Client()
+}
+
+func deposit(walletID, txid string, height int64) types.DepositEventPayload {
+	return types.DepositEventPayload{DepositEvent: types.DepositEvent{
+		Version:        types.V1,
+		WalletID:       walletID,
+		TxID:           txid,
+		Height:         height,
+		AmountZatoshis: 5_000,
+		Status:         types.TxStatus{State: types.TxStateConfirmed, Height: height},
+	}}
+}
+
+func TestContract_HealthAndWallets(t *testing.T) {
+	t.Parallel()
+	srv, cli := newServer(t)
+	ctx := context.Background()
+
+	health, err := cli.Health(ctx)
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if health.Status != "ok" || health.ScannedHeight != nil {
+		t.Fatalf("health=%+v", health)
+	}
+	srv.SetScanned(42, "ab")
+	health, err = cli.Health(ctx)
+	if err != nil || *health.ScannedHeight != 42 || *health.ScannedHash != "ab" {
+		t.Fatalf("health=%+v err=%v", health, err)
+	}
+
+	if err := cli.UpsertWallet(ctx, "hot", testUFVK(t)); err != nil {
+		t.Fatalf("UpsertWallet: %v", err)
+	}
+	srv.AddWallet("cold", "ufvk")
+	if err := srv.DisableWallet("cold"); err != nil {
+		t.Fatalf("DisableWallet: %v", err)
+	}
+	wallets, err := cli.ListWallets(ctx)
+	if err != nil {
+		t.Fatalf("ListWallets: %v", err)
+	}
+	if len(wallets) != 2 || wallets[0].WalletID != "cold" || wallets[0].DisabledAt == nil || wallets[1].WalletID != "hot" {
+		t.Fatalf("wallets=%+v", wallets)
+	}
+
+	var httpErr *junoscan.HTTPError
+	if _, err := cli.ListWalletEvents(ctx, "missing", 0, 10); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err=%v, want 404", err)
+	}
+	srv.FailNext("GET /v1/health", http.StatusServiceUnavailable, "scanner restarting")
+	if _, err := cli.Health(ctx); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable || httpErr.Body != "scanner restarting\n" {
+		t.Fatalf("err=%v, want injected 503", err)
+	}
+	if _, err := cli.Health(ctx); err != nil {
+		t.Fatalf("Health after failure: %v", err)
+	}
+}
+
+func TestContract_EventCursors(t *testing.T) {
+	t.Parallel()
+	srv, cli := newServer(t)
+	ctx := context.Background()
+	srv.AddWallet("a", "ufvk")
+	srv.AddWallet("b", "ufvk")
+
+	var ids []int64
+	for i, w := range []string{"a", "b", "a", "a", "b"} {
+		e, err := srv.EmitEvent(w, types.WalletEventKindDepositEvent, int64(10+i), deposit(w, "tx", int64(10+i)))
+		if err != nil {
+			t.Fatalf("EmitEvent: %v", err)
+		}
+		if w == "a" {
+			ids = append(ids, e.ID)
+		}
+	}
+
+	page, err := cli.ListWalletEvents(ctx, "a", 0, 2)
+	if err != nil {
+		t.Fatalf("ListWalletEvents: %v", err)
+	}
+	if len(page.Events) != 2 || page.Events[0].ID != ids[0] || page.NextCursor != ids[1] {
+		t.Fatalf("page=%+v", page)
+	}
+	page, err = cli.ListWalletEvents(ctx, "a", page.NextCursor, 2)
+	if err != nil || len(page.Events) != 1 || page.NextCursor != ids[2] {
+		t.Fatalf("page=%+v err=%v", page, err)
+	}
+	page, err = cli.ListWalletEvents(ctx, "a", page.NextCursor, 2)
+	if err != nil || len(page.Events) != 0 || page.NextCursor != ids[2] {
+		t.Fatalf("empty page=%+v err=%v", page, err)
+	}
+
+	var got []int64
+	for e, err := range cli.WalletEventsFrom(ctx, "a", 0) {
+		if err != nil {
+			t.Fatalf("WalletEventsFrom: %v", err)
+		}
+		p, err := types.As[types.DepositEventPayload](e.Kind, e.Payload)
+		if err != nil || p.WalletID != "a" || p.Height != e.Height {
+			t.Fatalf("payload=%+v err=%v", p, err)
+		}
+		got = append(got, e.ID)
+	}
+	if len(got) != 3 || got[2] != ids[2] {
+		t.Fatalf("ids=%v, want %v", got, ids)
+	}
+
+	if _, err := srv.EmitEvent("a", types.WalletEventKindSpendEvent, 1, deposit("a", "tx", 1)); err == nil {
+		t.Fatalf("expected payload type error")
+	}
+	if _, err := srv.EmitEvent("a", "FutureKind", 1, map[string]int{"x": 1}); err == nil {
+		t.Fatalf("expected unknown kind error")
+	}
+	e, err := srv.EmitEvent("a", "FutureKind", 1, json.RawMessage(`{"x":1}`))
+	if err != nil {
+		t.Fatalf("EmitEvent(raw): %v", err)
+	}
+	page, err = cli.ListWalletEvents(ctx, "a", ids[2], 10)
+	if err != nil || len(page.Events) != 1 || page.Events[0].Kind.Known() || page.NextCursor != e.ID {
+		t.Fatalf("page=%+v err=%v", page, err)
+	}
+}
+
+func TestContract_NotesAndSpends(t *testing.T) {
+	t.Parallel()
+	srv, cli := newServer(t)
+	ctx := context.Background()
+	srv.AddWallet("hot", "ufvk")
+
+	for i := range 5 {
+		if _, err := srv.AddNote("hot", junoscan.WalletNote{TxID: "in", ActionIndex: int32(i), Height: 100, ValueZat: int64(1_000 * (i + 1))}); err != nil {
+			t.Fatalf("AddNote: %v", err)
+		}
+	}
+	if _, err := srv.AddNote("hot", junoscan.WalletNote{TxID: "out", Direction: "outgoing", Height: 101, ValueZat: 700}); err != nil {
+		t.Fatalf("AddNote(outgoing): %v", err)
+	}
+	if _, err := srv.AddNote("hot", junoscan.WalletNote{TxID: "in", ActionIndex: 0}); err == nil {
+		t.Fatalf("expected duplicate error")
+	}
+	if err := srv.MarkPendingSpent("hot", "in", 1, "spend1", 130); err != nil {
+		t.Fatalf("MarkPendingSpent: %v", err)
+	}
+	if err := srv.MarkSpent("hot", "in", 2, "spend2", 110); err != nil {
+		t.Fatalf("MarkSpent: %v", err)
+	}
+	if err := srv.MarkSpent("hot", "in", 9, "spend", 110); !errors.Is(err, junoscantest.ErrNotFound) {
+		t.Fatalf("err=%v, want ErrNotFound", err)
+	}
+
+	var all []junoscan.WalletNote
+	for n, err := range cli.AllWalletNotes(ctx, "hot", junoscan.ListWalletNotesOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("AllWalletNotes: %v", err)
+		}
+		all = append(all, n)
+	}
+	if len(all) != 6 || all[5].Direction != "outgoing" || all[5].Position != nil || *all[4].Position != 4 {
+		t.Fatalf("notes=%+v", all)
+	}
+
+	unspent, err := cli.ListWalletNotes(ctx, "hot", true)
+	if err != nil {
+		t.Fatalf("ListWalletNotes: %v", err)
+	}
+	if len(unspent) != 4 || unspent[1].PendingSpentTxID == nil || *unspent[1].PendingSpentExpiryHeight != 130 {
+		t.Fatalf("unspent=%+v", unspent)
+	}
+	bal, err := junoscan.Balances(unspent, 120, junoscan.BalancePolicy{})
+	if err != nil {
+		t.Fatalf("Balances: %v", err)
+	}
+	if bal.PendingSpent != 2_000 || bal.PendingSpentNotes != 1 {
+		t.Fatalf("balances=%+v", bal)
+	}
+
+	page, err := cli.ListWalletNotesPage(ctx, "hot", junoscan.ListWalletNotesOptions{OnlyUnspent: true, MinValueZat: 4_000})
+	if err != nil || len(page.Notes) != 2 || page.NextCursor != "" {
+		t.Fatalf("page=%+v err=%v", page, err)
+	}
+
+	if err := srv.MarkUnspent("hot", "in", 2); err != nil {
+		t.Fatalf("MarkUnspent: %v", err)
+	}
+	if unspent, err = cli.ListWalletNotes(ctx, "hot", true); err != nil || len(unspent) != 5 {
+		t.Fatalf("unspent=%d err=%v", len(unspent), err)
+	}
+}
+
+func TestContract_Witness(t *testing.T) {
+	t.Parallel()
+	srv, cli := newServer(t)
+	ctx := context.Background()
+	srv.AddWallet("hot", "ufvk")
+
+	var other [32]byte
+	other[0] = 7
+	if _, err := srv.AppendCommitments(50, other, other, other); err != nil {
+		t.Fatalf("AppendCommitments: %v", err)
+	}
+	var positions []uint32
+	for i := range 5 {
+		n, err := srv.AddNote("hot", junoscan.WalletNote{TxID: "in", ActionIndex: int32(i), Height: int64(60 + i), ValueZat: 1})
+		if err != nil {
+			t.Fatalf("AddNote: %v", err)
+		}
+		positions = append(positions, uint32(*n.Position))
+	}
+	if _, err := srv.AppendCommitments(10, other); err == nil {
+		t.Fatalf("expected height order error")
+	}
+
+	var httpErr *junoscan.HTTPError
+	if _, err := cli.OrchardWitness(ctx, nil, positions); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err=%v, want 503 before the first scan", err)
+	}
+	srv.SetScanned(70, "tip")
+
+	resp, err := cli.OrchardWitnessBatch(ctx, nil, positions, junoscan.WitnessBatchOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("OrchardWitnessBatch: %v", err)
+	}
+	if resp.AnchorHeight != 70 || len(resp.Paths) != len(positions) {
+		t.Fatalf("resp=%+v", resp)
+	}
+	for _, p := range resp.Paths {
+		cmx, ok := srv.Commitment(p.Position)
+		if !ok {
+			t.Fatalf("no commitment at %d", p.Position)
+		}
+		if err := orchard.VerifyWitness(resp.Root, hex.EncodeToString(cmx[:]), p.Position, p.AuthPath); err != nil {
+			t.Fatalf("VerifyWitness(%d): %v", p.Position, err)
+		}
+	}
+
+	// An older anchor only covers the commitments mined by then.
+	anchor := int64(61)
+	old, err := cli.OrchardWitness(ctx, &anchor, positions[:2])
+	if err != nil {
+		t.Fatalf("OrchardWitness(61): %v", err)
+	}
+	if old.Root == resp.Root {
+		t.Fatalf("root at 61 equals root at 70")
+	}
+	cmx, _ := srv.Commitment(positions[1])
+	if err := orchard.VerifyWitness(old.Root, hex.EncodeToString(cmx[:]), positions[1], old.Paths[1].AuthPath); err != nil {
+		t.Fatalf("VerifyWitness at 61: %v", err)
+	}
+	if _, err := cli.OrchardWitness(ctx, &anchor, positions[2:3]); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("err=%v, want 400 for a later position", err)
+	}
+	beyond := int64(71)
+	if _, err := cli.OrchardWitness(ctx, &beyond, positions); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("err=%v, want 400 beyond the scanned height", err)
+	}
+}
@@ -0,0 +1,67 @@
+package junoscantest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Abdullah1738/juno-sdk-go/orchard"
+)
+
+// leaf is one note commitment of the Orchard tree, with the height of the block that added it.
+type leaf struct {
+	cmx    [32]byte
+	height int64
+}
+
+// derivedCMX returns a canonical stand-in commitment for a note added without one.
+func derivedCMX(txid string, actionIndex int32) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(txid))
+	_ = binary.Write(h, binary.LittleEndian, actionIndex)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	out[31] &= 0x3f // below 2^254, so below the Pallas base field modulus
+	return out
+}
+
+// witness computes the root of the tree made of leaves and the auth paths of positions in it.
+func witness(leaves []leaf, positions []uint32) (string, [][]string, error) {
+	level := make([][32]byte, len(leaves))
+	for i, l := range leaves {
+		level[i] = l.cmx
+	}
+	paths := make([][]string, len(positions))
+	for altitude := range uint8(orchard.MerkleDepth) {
+		empty := orchard.EmptyRoot(altitude)
+		for i, pos := range positions {
+			sibling := int(pos>>altitude) ^ 1
+			node := empty
+			if sibling < len(level) {
+				node = level[sibling]
+			}
+			paths[i] = append(paths[i], hex.EncodeToString(node[:]))
+		}
+
+		next := make([][32]byte, (len(level)+1)/2)
+		for i := range next {
+			right := empty
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+			h, err := orchard.MerkleHash(altitude, level[2*i], right)
+			if err != nil {
+				return "", nil, fmt.Errorf("hash altitude %d: %w", altitude, err)
+			}
+			next[i] = h
+		}
+		level = next
+	}
+
+	root := orchard.EmptyRoot(orchard.MerkleDepth)
+	if len(level) > 0 {
+		root = level[0]
+	}
+	return hex.EncodeToString(root[:]), paths, nil
+}
@@ -2,6 +2,7 @@ package junoscan
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/Abdullah1738/juno-sdk-go/types"
@@ -27,6 +28,12 @@ type WalletEvent struct {
 	CreatedAt time.Time             `json:"created_at"`
 }
 
+// DecodePayload decodes e.Payload into the types payload struct registered for e.Kind.
+// See types.DecodePayload.
+func (e WalletEvent) DecodePayload() (any, error) {
+	return types.DecodePayload(e.Kind, e.Payload)
+}
+
 type WalletEventsPage struct {
 	Events     []WalletEvent `json:"events"`
 	NextCursor int64         `json:"next_cursor"`
@@ -83,3 +90,27 @@ type walletRequest struct {
 	WalletID string `json:"wallet_id"`
 	UFVK     string `json:"ufvk"`
 }
+
+// Value returns ValueZat as a range-checked types.Zatoshi.
+func (n WalletNote) Value() (types.Zatoshi, error) {
+	v := types.Zatoshi(n.ValueZat)
+	if !v.Valid() {
+		return 0, fmt.Errorf("%w: %d", types.ErrAmountOutOfRange, n.ValueZat)
+	}
+	return v, nil
+}
+
+// TotalValue returns the checked sum of the notes' values.
+func TotalValue(notes []WalletNote) (types.Zatoshi, error) {
+	var total types.Zatoshi
+	for _, n := range notes {
+		v, err := n.Value()
+		if err != nil {
+			return 0, err
+		}
+		if total, err = total.Add(v); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
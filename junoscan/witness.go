@@ -0,0 +1,250 @@
+package junoscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+const (
+	defaultWitnessChunkSize   = 64
+	defaultWitnessConcurrency = 4
+	witnessCacheAnchors       = 8
+)
+
+// ErrWitnessRootMismatch is returned by OrchardWitnessBatch when chunks pinned to the same anchor
+// height report different roots, e.g. because the block at that height was reorged away.
+var ErrWitnessRootMismatch = errors.New("junoscan: orchard witness root mismatch")
+
+type WitnessBatchOptions struct {
+	// ChunkSize is the number of positions per request. Zero means 64.
+	ChunkSize int
+	// Concurrency is the number of requests in flight. Zero means 4.
+	Concurrency int
+}
+
+// OrchardWitnessBatch fetches auth paths for positions in chunks, concurrently. All chunks are
+// pinned to the same anchor height: if anchorHeight is nil, the first chunk is fetched alone at
+// the scanner's default anchor and the rest are pinned to the height it reports.
+//
+// Paths are cached by anchor height, root and position, so a retry after a partial failure only
+// fetches the missing positions. One chunk is fetched on every call to learn the current root;
+// cached paths for another root, e.g. from before a reorg, are discarded. The returned paths
+// follow the order of positions, without duplicates.
+func (c *Client) OrchardWitnessBatch(ctx context.Context, anchorHeight *int64, positions []uint32, opts WitnessBatchOptions) (OrchardWitnessResponse, error) {
+	if len(positions) == 0 {
+		return OrchardWitnessResponse{}, errors.New("junoscan: positions required")
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = defaultWitnessChunkSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultWitnessConcurrency
+	}
+
+	var unique []uint32
+	seen := make(map[uint32]bool, len(positions))
+	for _, p := range positions {
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+
+	// Something is always fetched, even when every position is cached, so that the root at the
+	// anchor height is current: after a reorg the block at that height has a new root and the
+	// cached paths are stale.
+	probe := unique
+	if anchorHeight != nil {
+		probe = c.witnessCache.missing(*anchorHeight, unique)
+		if len(probe) == 0 {
+			probe = unique[:1]
+		}
+	}
+	probe = probe[:min(opts.ChunkSize, len(probe))]
+	resp, err := c.OrchardWitness(ctx, anchorHeight, probe)
+	if err != nil {
+		return OrchardWitnessResponse{}, err
+	}
+	if anchorHeight != nil && resp.AnchorHeight != *anchorHeight {
+		return OrchardWitnessResponse{}, fmt.Errorf("junoscan: witness for anchor height %d returned height %d", *anchorHeight, resp.AnchorHeight)
+	}
+	height, root := resp.AnchorHeight, resp.Root
+	if err := c.witnessCache.add(resp, probe); err != nil {
+		return OrchardWitnessResponse{}, err
+	}
+
+	missing := c.witnessCache.missing(height, unique)
+	if err := c.fetchWitnessChunks(ctx, height, root, missing, opts); err != nil {
+		return OrchardWitnessResponse{}, err
+	}
+
+	cached, paths, ok := c.witnessCache.get(height, unique)
+	if !ok || cached != root {
+		return OrchardWitnessResponse{}, fmt.Errorf("junoscan: witness cache lost anchor height %d", height)
+	}
+	out := OrchardWitnessResponse{Status: "ok", AnchorHeight: height, Root: root}
+	for i, p := range unique {
+		out.Paths = append(out.Paths, OrchardWitnessPath{Position: p, AuthPath: paths[i]})
+	}
+	return out, nil
+}
+
+func (c *Client) fetchWitnessChunks(ctx context.Context, height int64, root string, missing []uint32, opts WitnessBatchOptions) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		sem      = make(chan struct{}, opts.Concurrency)
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for chunk := range slices.Chunk(missing, opts.ChunkSize) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(chunk []uint32) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			h := height
+			resp, err := c.OrchardWitness(ctx, &h, chunk)
+			if err != nil {
+				fail(err)
+				return
+			}
+			if resp.AnchorHeight != height {
+				fail(fmt.Errorf("junoscan: witness for anchor height %d returned height %d", height, resp.AnchorHeight))
+				return
+			}
+			if resp.Root != root {
+				c.witnessCache.evict(height)
+				fail(fmt.Errorf("%w at anchor height %d: %s != %s", ErrWitnessRootMismatch, height, resp.Root, root))
+				return
+			}
+			if err := c.witnessCache.add(resp, chunk); err != nil {
+				fail(err)
+			}
+		}(chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+type witnessAnchor struct {
+	root  string
+	paths map[uint32][]string
+}
+
+// witnessCache holds auth paths for the most recently used anchor heights, together with the
+// root they lead to. Only the latest root seen at a height is kept.
+type witnessCache struct {
+	mu      sync.Mutex
+	anchors map[int64]*witnessAnchor
+	order   []int64
+}
+
+func newWitnessCache() *witnessCache {
+	return &witnessCache{anchors: map[int64]*witnessAnchor{}}
+}
+
+// add stores the paths of resp for the requested positions. If resp has a different root than
+// the one cached for its height, the paths cached for the old root are dropped.
+func (wc *witnessCache) add(resp OrchardWitnessResponse, requested []uint32) error {
+	got := make(map[uint32][]string, len(resp.Paths))
+	for _, p := range resp.Paths {
+		got[p.Position] = p.AuthPath
+	}
+	for _, p := range requested {
+		if _, ok := got[p]; !ok {
+			return fmt.Errorf("junoscan: witness response missing position %d", p)
+		}
+	}
+
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	a, ok := wc.anchors[resp.AnchorHeight]
+	if ok && a.root != resp.Root {
+		a.root, a.paths = resp.Root, map[uint32][]string{}
+	}
+	if !ok {
+		a = &witnessAnchor{root: resp.Root, paths: map[uint32][]string{}}
+		wc.anchors[resp.AnchorHeight] = a
+		wc.order = append(wc.order, resp.AnchorHeight)
+		for len(wc.order) > witnessCacheAnchors {
+			wc.evictLocked(wc.order[0])
+		}
+	}
+	for p, path := range got {
+		a.paths[p] = path
+	}
+	return nil
+}
+
+func (wc *witnessCache) missing(height int64, positions []uint32) []uint32 {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	a := wc.anchors[height]
+	if a == nil {
+		return positions
+	}
+	var out []uint32
+	for _, p := range positions {
+		if _, ok := a.paths[p]; !ok {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (wc *witnessCache) get(height int64, positions []uint32) (string, [][]string, bool) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	a := wc.anchors[height]
+	if a == nil {
+		return "", nil, false
+	}
+	out := make([][]string, len(positions))
+	for i, p := range positions {
+		path, ok := a.paths[p]
+		if !ok {
+			return "", nil, false
+		}
+		out[i] = path
+	}
+	return a.root, out, true
+}
+
+func (wc *witnessCache) evict(height int64) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.evictLocked(height)
+}
+
+func (wc *witnessCache) evictLocked(height int64) {
+	delete(wc.anchors, height)
+	if i := slices.Index(wc.order, height); i >= 0 {
+		wc.order = slices.Delete(wc.order, i, i+1)
+	}
+}
@@ -0,0 +1,224 @@
+package junoscan_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+)
+
+type witnessServer struct {
+	mu       sync.Mutex
+	requests []junoscan.WitnessRequest
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+
+	// rootFor and fail customize responses per request.
+	rootFor func(req junoscan.WitnessRequest) string
+	fail    func(req junoscan.WitnessRequest) bool
+}
+
+func (ws *witnessServer) start(t *testing.T) *junoscan.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := ws.inFlight.Add(1)
+		defer ws.inFlight.Add(-1)
+		for {
+			m := ws.maxSeen.Load()
+			if n <= m || ws.maxSeen.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var req junoscan.WitnessRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad json", http.StatusBadRequest)
+			return
+		}
+		ws.mu.Lock()
+		ws.requests = append(ws.requests, req)
+		ws.mu.Unlock()
+
+		if ws.fail != nil && ws.fail(req) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		height := int64(123)
+		if req.AnchorHeight != nil {
+			height = *req.AnchorHeight
+		}
+		root := strings.Repeat("a", 64)
+		if ws.rootFor != nil {
+			root = ws.rootFor(req)
+		}
+		resp := junoscan.OrchardWitnessResponse{Status: "ok", AnchorHeight: height, Root: root}
+		for _, p := range req.Positions {
+			resp.Paths = append(resp.Paths, junoscan.OrchardWitnessPath{Position: p, AuthPath: []string{fmt.Sprintf("%d@%d", p, height)}})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := junoscan.New(srv.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func (ws *witnessServer) reset() []junoscan.WitnessRequest {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	out := ws.requests
+	ws.requests = nil
+	return out
+}
+
+func positionsUpTo(n int) []uint32 {
+	out := make([]uint32, n)
+	for i := range out {
+		out[i] = uint32(i * 7)
+	}
+	return out
+}
+
+func TestClient_OrchardWitnessBatch(t *testing.T) {
+	ws := &witnessServer{}
+	c := ws.start(t)
+	ctx := context.Background()
+	positions := append(positionsUpTo(20), 7) // duplicate
+
+	got, err := c.OrchardWitnessBatch(ctx, nil, positions, junoscan.WitnessBatchOptions{ChunkSize: 3, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("OrchardWitnessBatch: %v", err)
+	}
+	if got.AnchorHeight != 123 || got.Root != strings.Repeat("a", 64) || len(got.Paths) != 20 {
+		t.Fatalf("resp anchor=%d paths=%d", got.AnchorHeight, len(got.Paths))
+	}
+	for i, p := range got.Paths {
+		if p.Position != uint32(i*7) || p.AuthPath[0] != fmt.Sprintf("%d@123", i*7) {
+			t.Fatalf("path %d = %+v", i, p)
+		}
+	}
+
+	reqs := ws.reset()
+	if len(reqs) != 7 {
+		t.Fatalf("requests=%d, want 7", len(reqs))
+	}
+	if reqs[0].AnchorHeight != nil {
+		t.Fatalf("first request should use the default anchor")
+	}
+	for _, r := range reqs[1:] {
+		if r.AnchorHeight == nil || *r.AnchorHeight != 123 {
+			t.Fatalf("chunk not pinned: %+v", r)
+		}
+		if len(r.Positions) > 3 {
+			t.Fatalf("chunk too large: %v", r.Positions)
+		}
+	}
+	if m := ws.maxSeen.Load(); m > 2 {
+		t.Fatalf("max concurrency=%d", m)
+	}
+
+	// Everything is cached now; only one position is fetched to check the root.
+	h := int64(123)
+	if _, err := c.OrchardWitnessBatch(ctx, &h, positions[:5], junoscan.WitnessBatchOptions{}); err != nil {
+		t.Fatalf("cached: %v", err)
+	}
+	if reqs := ws.reset(); len(reqs) != 1 || len(reqs[0].Positions) != 1 {
+		t.Fatalf("cached call made requests %+v", reqs)
+	}
+}
+
+func TestClient_OrchardWitnessBatchAfterReorg(t *testing.T) {
+	var root atomic.Value
+	root.Store(strings.Repeat("a", 64))
+	ws := &witnessServer{rootFor: func(junoscan.WitnessRequest) string { return root.Load().(string) }}
+	c := ws.start(t)
+	ctx := context.Background()
+	h := int64(400)
+	positions := positionsUpTo(6)
+	opts := junoscan.WitnessBatchOptions{ChunkSize: 2, Concurrency: 1}
+
+	if _, err := c.OrchardWitnessBatch(ctx, &h, positions, opts); err != nil {
+		t.Fatalf("OrchardWitnessBatch: %v", err)
+	}
+	ws.reset()
+
+	// The block at the anchor height is reorged away, so the tree there has a new root.
+	root.Store(strings.Repeat("c", 64))
+	got, err := c.OrchardWitnessBatch(ctx, &h, positions, opts)
+	if err != nil {
+		t.Fatalf("after reorg: %v", err)
+	}
+	if got.Root != strings.Repeat("c", 64) {
+		t.Fatalf("root=%s, want the post-reorg root", got.Root)
+	}
+	var refetched []uint32
+	for _, r := range ws.reset() {
+		refetched = append(refetched, r.Positions...)
+	}
+	slices.Sort(refetched)
+	if !slices.Equal(refetched, positions) {
+		t.Fatalf("refetched %v, want every position", refetched)
+	}
+}
+
+func TestClient_OrchardWitnessBatchRetryUsesCache(t *testing.T) {
+	var failed atomic.Bool
+	ws := &witnessServer{fail: func(req junoscan.WitnessRequest) bool {
+		return slices.Contains(req.Positions, 35) && failed.CompareAndSwap(false, true)
+	}}
+	c := ws.start(t)
+	ctx := context.Background()
+	h := int64(200)
+	positions := positionsUpTo(12)
+	opts := junoscan.WitnessBatchOptions{ChunkSize: 4, Concurrency: 1}
+
+	if _, err := c.OrchardWitnessBatch(ctx, &h, positions, opts); err == nil {
+		t.Fatalf("expected error from failing chunk")
+	}
+	ws.reset()
+
+	got, err := c.OrchardWitnessBatch(ctx, &h, positions, opts)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(got.Paths) != 12 {
+		t.Fatalf("paths=%d", len(got.Paths))
+	}
+	var refetched []uint32
+	for _, r := range ws.reset() {
+		refetched = append(refetched, r.Positions...)
+	}
+	if !slices.Contains(refetched, 35) || len(refetched) >= 12 {
+		t.Fatalf("retry refetched %v", refetched)
+	}
+}
+
+func TestClient_OrchardWitnessBatchRootMismatch(t *testing.T) {
+	ws := &witnessServer{rootFor: func(req junoscan.WitnessRequest) string {
+		if slices.Contains(req.Positions, 0) {
+			return strings.Repeat("a", 64)
+		}
+		return strings.Repeat("b", 64)
+	}}
+	c := ws.start(t)
+	h := int64(300)
+
+	_, err := c.OrchardWitnessBatch(context.Background(), &h, positionsUpTo(6), junoscan.WitnessBatchOptions{ChunkSize: 2, Concurrency: 1})
+	if !errors.Is(err, junoscan.ErrWitnessRootMismatch) {
+		t.Fatalf("err=%v, want ErrWitnessRootMismatch", err)
+	}
+}
@@ -0,0 +1,148 @@
+package junotx
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type BlockHeader struct {
+	Version          int32
+	PrevBlock        [32]byte
+	MerkleRoot       [32]byte
+	BlockCommitments [32]byte
+	Time             uint32
+	Bits             uint32
+	Nonce            [32]byte
+	Solution         []byte
+}
+
+type Block struct {
+	Header       BlockHeader
+	Transactions []*Transaction
+}
+
+// ParseBlockHeader parses a serialized block header. Trailing bytes are an error.
+func ParseBlockHeader(b []byte) (*BlockHeader, error) {
+	r := &reader{buf: b}
+	h, err := readBlockHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.remaining() != 0 {
+		return nil, fmt.Errorf("junotx: %d trailing bytes after block header", r.remaining())
+	}
+	return h, nil
+}
+
+// ParseBlock parses a serialized block, as returned by getblock with verbosity 0.
+func ParseBlock(b []byte) (*Block, error) {
+	r := &reader{buf: b}
+	h, err := readBlockHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.count(1)
+	if err != nil {
+		return nil, err
+	}
+	block := &Block{Header: *h, Transactions: make([]*Transaction, n)}
+	for i := range block.Transactions {
+		tx, err := readTransaction(r)
+		if err != nil {
+			return nil, fmt.Errorf("junotx: block tx %d: %w", i, err)
+		}
+		block.Transactions[i] = tx
+	}
+	if r.remaining() != 0 {
+		return nil, fmt.Errorf("junotx: %d trailing bytes after block", r.remaining())
+	}
+	return block, nil
+}
+
+func ParseBlockHex(s string) (*Block, error) {
+	b, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("junotx: invalid hex: %w", err)
+	}
+	return ParseBlock(b)
+}
+
+func (h *BlockHeader) Serialize() []byte {
+	w := &writer{}
+	h.write(w)
+	return w.buf
+}
+
+func (b *Block) Serialize() []byte {
+	w := &writer{}
+	b.Header.write(w)
+	w.compactSize(uint64(len(b.Transactions)))
+	for _, tx := range b.Transactions {
+		tx.write(w)
+	}
+	return w.buf
+}
+
+func readBlockHeader(r *reader) (*BlockHeader, error) {
+	h := &BlockHeader{}
+	version, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	h.Version = int32(version)
+	for _, dst := range [][]byte{h.PrevBlock[:], h.MerkleRoot[:], h.BlockCommitments[:]} {
+		if err := r.copyInto(dst); err != nil {
+			return nil, err
+		}
+	}
+	if h.Time, err = r.uint32(); err != nil {
+		return nil, err
+	}
+	if h.Bits, err = r.uint32(); err != nil {
+		return nil, err
+	}
+	if err := r.copyInto(h.Nonce[:]); err != nil {
+		return nil, err
+	}
+	if h.Solution, err = r.varBytes(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *BlockHeader) write(w *writer) {
+	w.uint32(uint32(h.Version))
+	w.write(h.PrevBlock[:])
+	w.write(h.MerkleRoot[:])
+	w.write(h.BlockCommitments[:])
+	w.uint32(h.Time)
+	w.uint32(h.Bits)
+	w.write(h.Nonce[:])
+	w.varBytes(h.Solution)
+}
+
+// HashToHex formats an internal-order hash the way the node RPC displays it (byte-reversed).
+func HashToHex(h [32]byte) string {
+	for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+		h[i], h[j] = h[j], h[i]
+	}
+	return hex.EncodeToString(h[:])
+}
+
+// HashFromHex parses an RPC-formatted (byte-reversed) hash into internal order.
+func HashFromHex(s string) ([32]byte, error) {
+	var h [32]byte
+	b, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return h, fmt.Errorf("junotx: invalid hash hex: %w", err)
+	}
+	if len(b) != len(h) {
+		return h, fmt.Errorf("junotx: hash must be 32 bytes, got %d", len(b))
+	}
+	for i := range b {
+		h[i] = b[len(b)-1-i]
+	}
+	return h, nil
+}
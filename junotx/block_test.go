@@ -0,0 +1,61 @@
+package junotx_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+)
+
+func TestParseBlock(t *testing.T) {
+	raw := readFixture(t, "block_v5.hex")
+
+	block, err := junotx.ParseBlock(raw)
+	if err != nil {
+		t.Fatalf("ParseBlock: %v", err)
+	}
+	if block.Header.Version != 4 || block.Header.Time != 1760000000 || block.Header.Bits != 0x200f0f0f {
+		t.Fatalf("header=%+v", block.Header)
+	}
+	if len(block.Header.Solution) != 32 {
+		t.Fatalf("solution len=%d", len(block.Header.Solution))
+	}
+	if len(block.Transactions) != 2 {
+		t.Fatalf("txs=%d", len(block.Transactions))
+	}
+	if !block.Transactions[0].IsCoinbase() || block.Transactions[1].Orchard == nil {
+		t.Fatalf("unexpected transactions")
+	}
+	if got := block.Serialize(); !bytes.Equal(got, raw) {
+		t.Fatalf("round-trip mismatch")
+	}
+
+	headerLen := len(block.Header.Serialize())
+	header, err := junotx.ParseBlockHeader(raw[:headerLen])
+	if err != nil {
+		t.Fatalf("ParseBlockHeader: %v", err)
+	}
+	if header.PrevBlock != block.Header.PrevBlock {
+		t.Fatalf("prev block mismatch")
+	}
+	if _, err := junotx.ParseBlock(raw[:len(raw)-10]); err == nil {
+		t.Fatalf("expected truncation error")
+	}
+}
+
+func TestHashHex(t *testing.T) {
+	const s = "00000000000000000000000000000000000000000000000000000000000000ff"
+	h, err := junotx.HashFromHex(s)
+	if err != nil {
+		t.Fatalf("HashFromHex: %v", err)
+	}
+	if h[0] != 0xff {
+		t.Fatalf("expected reversed byte order")
+	}
+	if got := junotx.HashToHex(h); got != s {
+		t.Fatalf("HashToHex=%q", got)
+	}
+	if _, err := junotx.HashFromHex("00"); err == nil {
+		t.Fatalf("expected length error")
+	}
+}
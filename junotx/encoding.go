@@ -0,0 +1,184 @@
+package junotx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const maxCompactSize = 0x02000000
+
+var ErrTruncated = errors.New("junotx: unexpected end of data")
+
+type reader struct {
+	buf []byte
+	off int
+}
+
+func (r *reader) remaining() int {
+	return len(r.buf) - r.off
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.remaining() < n {
+		return nil, ErrTruncated
+	}
+	b := r.buf[r.off : r.off+n]
+	r.off += n
+	return b, nil
+}
+
+func (r *reader) copyInto(dst []byte) error {
+	b, err := r.bytes(len(dst))
+	if err != nil {
+		return err
+	}
+	copy(dst, b)
+	return nil
+}
+
+func (r *reader) byte() (byte, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *reader) uint16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *reader) uint64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (r *reader) int64() (int64, error) {
+	v, err := r.uint64()
+	return int64(v), err
+}
+
+func (r *reader) compactSize() (uint64, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+
+	var v, min uint64
+	switch tag {
+	case 0xfd:
+		n, err := r.uint16()
+		if err != nil {
+			return 0, err
+		}
+		v, min = uint64(n), 0xfd
+	case 0xfe:
+		n, err := r.uint32()
+		if err != nil {
+			return 0, err
+		}
+		v, min = uint64(n), 0x10000
+	case 0xff:
+		n, err := r.uint64()
+		if err != nil {
+			return 0, err
+		}
+		v, min = n, 0x100000000
+	default:
+		return uint64(tag), nil
+	}
+	if v < min {
+		return 0, errors.New("junotx: non-canonical compact size")
+	}
+	if v > maxCompactSize {
+		return 0, fmt.Errorf("junotx: compact size %d too large", v)
+	}
+	return v, nil
+}
+
+// count reads a compact size that prefixes n items of at least itemSize bytes each.
+func (r *reader) count(itemSize int) (int, error) {
+	n, err := r.compactSize()
+	if err != nil {
+		return 0, err
+	}
+	if itemSize > 0 && n > uint64(r.remaining()/itemSize) {
+		return 0, ErrTruncated
+	}
+	return int(n), nil
+}
+
+func (r *reader) varBytes() ([]byte, error) {
+	n, err := r.count(1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.bytes(n)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+type writer struct {
+	buf []byte
+}
+
+func (w *writer) write(b []byte) {
+	w.buf = append(w.buf, b...)
+}
+
+func (w *writer) byte(b byte) {
+	w.buf = append(w.buf, b)
+}
+
+func (w *writer) uint32(v uint32) {
+	w.buf = binary.LittleEndian.AppendUint32(w.buf, v)
+}
+
+func (w *writer) uint64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *writer) int64(v int64) {
+	w.uint64(uint64(v))
+}
+
+func (w *writer) compactSize(v uint64) {
+	w.buf = AppendCompactSize(w.buf, v)
+}
+
+func (w *writer) varBytes(b []byte) {
+	w.compactSize(uint64(len(b)))
+	w.write(b)
+}
+
+// AppendCompactSize appends the Bitcoin-style CompactSize encoding of v to b.
+func AppendCompactSize(b []byte, v uint64) []byte {
+	switch {
+	case v < 0xfd:
+		return append(b, byte(v))
+	case v <= 0xffff:
+		return binary.LittleEndian.AppendUint16(append(b, 0xfd), uint16(v))
+	case v <= 0xffffffff:
+		return binary.LittleEndian.AppendUint32(append(b, 0xfe), uint32(v))
+	default:
+		return binary.LittleEndian.AppendUint64(append(b, 0xff), v)
+	}
+}
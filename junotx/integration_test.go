@@ -0,0 +1,90 @@
+//go:build integration
+
+package junotx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+)
+
+func TestParseBlock_Integration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	r, err := junoregtest.Start(ctx, junoregtest.Config{})
+	if err != nil {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
+			t.Skip("junocashd not found in PATH")
+		}
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = r.Stop(context.Background()) }()
+
+	cli := junocashd.New(r.RPCURL, r.RPCUser, r.RPCPassword)
+
+	var hashes []string
+	if err := cli.Call(ctx, "generate", []any{1}, &hashes); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(hashes) != 1 {
+		t.Fatalf("generated=%v", hashes)
+	}
+
+	raw, err := cli.GetBlockRaw(ctx, hashes[0])
+	if err != nil {
+		t.Fatalf("GetBlockRaw: %v", err)
+	}
+	block, err := junotx.ParseBlock(raw)
+	if err != nil {
+		t.Fatalf("ParseBlock: %v", err)
+	}
+	if !bytes.Equal(block.Serialize(), raw) {
+		t.Fatalf("round-trip mismatch")
+	}
+
+	verbose, err := cli.GetBlockVerbose(ctx, hashes[0])
+	if err != nil {
+		t.Fatalf("GetBlockVerbose: %v", err)
+	}
+	if len(verbose.Tx) != len(block.Transactions) {
+		t.Fatalf("txs=%d want %d", len(block.Transactions), len(verbose.Tx))
+	}
+	if junotx.HashToHex(block.Header.PrevBlock) != verbose.PreviousBlockHash {
+		t.Fatalf("prev block mismatch")
+	}
+
+	txHex, err := cli.GetRawTransactionHex(ctx, verbose.Tx[0])
+	if err != nil {
+		t.Fatalf("GetRawTransactionHex: %v", err)
+	}
+	tx, err := junotx.ParseTransactionHex(txHex)
+	if err != nil {
+		t.Fatalf("ParseTransactionHex: %v", err)
+	}
+	if !tx.IsCoinbase() {
+		t.Fatalf("expected coinbase")
+	}
+
+	nodeTx, err := cli.GetRawTransactionVerbose(ctx, verbose.Tx[0])
+	if err != nil {
+		t.Fatalf("GetRawTransactionVerbose: %v", err)
+	}
+	if got := tx.TxID(); got != nodeTx.TxID {
+		t.Fatalf("txid=%s want %s", got, nodeTx.TxID)
+	}
+	if nodeTx.AuthDigest != "" && tx.AuthDigestHex() != nodeTx.AuthDigest {
+		t.Fatalf("authdigest=%s want %s", tx.AuthDigestHex(), nodeTx.AuthDigest)
+	}
+	for i, blockTx := range block.Transactions {
+		if got := blockTx.TxID(); got != verbose.Tx[i] {
+			t.Fatalf("block tx %d txid=%s want %s", i, got, verbose.Tx[i])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+//go:build integration
+
+package junotx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+var update = flag.Bool("update", false, "rewrite the regtest fixtures in testdata")
+
+// TestCaptureRegtestFixtures_Integration funds an Orchard address on a regtest node and checks
+// that the parser round-trips the funding transaction and its block, and that the ZIP-244 txid
+// and auth digest match the node's. With -update it stores them as the regtest fixtures.
+func TestCaptureRegtestFixtures_Integration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	node, err := junoregtest.Start(ctx, junoregtest.Config{Args: []string{"-txindex=1"}})
+	if err != nil {
+		if errors.Is(err, junoregtest.ErrJunocashdNotFound) {
+			t.Skip("junocashd not found in PATH")
+		}
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = node.Stop(context.Background()) }()
+	cli := node.Client()
+
+	acct, err := node.NewOrchardAccount(ctx)
+	if err != nil {
+		t.Fatalf("NewOrchardAccount: %v", err)
+	}
+	txid, err := node.Fund(ctx, acct.Address, types.ZatoshiPerJUNO)
+	if err != nil {
+		t.Fatalf("Fund: %v", err)
+	}
+
+	nodeTx, err := cli.GetRawTransactionVerbose(ctx, txid)
+	if err != nil {
+		t.Fatalf("GetRawTransactionVerbose: %v", err)
+	}
+	rawTx, err := hex.DecodeString(nodeTx.Hex)
+	if err != nil {
+		t.Fatalf("decode tx hex: %v", err)
+	}
+	tx, err := junotx.ParseTransaction(rawTx)
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	if tx.Orchard == nil || len(tx.Orchard.Actions) == 0 {
+		t.Fatalf("funding transaction has no Orchard actions")
+	}
+	if !bytes.Equal(tx.Serialize(), rawTx) {
+		t.Fatalf("tx round-trip mismatch")
+	}
+	if got := tx.TxID(); got != txid || got != nodeTx.TxID {
+		t.Fatalf("txid=%s want %s", got, nodeTx.TxID)
+	}
+	if got := tx.AuthDigestHex(); got != nodeTx.AuthDigest {
+		t.Fatalf("authdigest=%s want %s", got, nodeTx.AuthDigest)
+	}
+
+	rawBlock, err := cli.GetBlockRaw(ctx, nodeTx.BlockHash)
+	if err != nil {
+		t.Fatalf("GetBlockRaw: %v", err)
+	}
+	block, err := junotx.ParseBlock(rawBlock)
+	if err != nil {
+		t.Fatalf("ParseBlock: %v", err)
+	}
+	if !bytes.Equal(block.Serialize(), rawBlock) {
+		t.Fatalf("block round-trip mismatch")
+	}
+
+	if !*update {
+		return
+	}
+	meta, err := json.MarshalIndent(regtestFixture{
+		TxID:       nodeTx.TxID,
+		AuthDigest: nodeTx.AuthDigest,
+		BlockHash:  nodeTx.BlockHash,
+		Height:     nodeTx.Height,
+	}, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal fixture: %v", err)
+	}
+	files := map[string][]byte{
+		regtestTxFixture:    []byte(hex.EncodeToString(rawTx) + "\n"),
+		regtestBlockFixture: []byte(hex.EncodeToString(rawBlock) + "\n"),
+		regtestMetaFixture:  append(meta, '\n'),
+	}
+	for name, b := range files {
+		if err := os.WriteFile(filepath.Join("testdata", name), b, 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
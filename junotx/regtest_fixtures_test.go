@@ -0,0 +1,84 @@
+package junotx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+)
+
+// The regtest fixtures are encodings captured from a regtest junocashd. Regenerate them with
+//
+//	go test -tags integration ./junotx -run TestCaptureRegtestFixtures -update
+const (
+	regtestTxFixture    = "regtest_orchard_v5.hex"
+	regtestBlockFixture = "regtest_block_v5.hex"
+	regtestMetaFixture  = "regtest_orchard_v5.json"
+)
+
+// regtestFixture holds what the node reported for the captured transaction.
+type regtestFixture struct {
+	TxID       string `json:"txid"`
+	AuthDigest string `json:"authdigest"`
+	BlockHash  string `json:"blockhash"`
+	Height     int64  `json:"height"`
+}
+
+func readRegtestFixture(t *testing.T) (tx, block []byte, meta regtestFixture) {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", regtestMetaFixture))
+	if os.IsNotExist(err) {
+		t.Skip("regtest fixtures not captured; run TestCaptureRegtestFixtures_Integration with -update")
+	}
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	return readFixture(t, regtestTxFixture), readFixture(t, regtestBlockFixture), meta
+}
+
+func TestParseTransaction_Regtest(t *testing.T) {
+	raw, _, _ := readRegtestFixture(t)
+
+	tx, err := junotx.ParseTransaction(raw)
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	if tx.Version != junotx.TxVersion5 || tx.VersionGroupID != junotx.TxVersionGroupID5 {
+		t.Fatalf("version=%d group=%x", tx.Version, tx.VersionGroupID)
+	}
+	if tx.Orchard == nil || len(tx.Orchard.Actions) == 0 || len(tx.Orchard.Proof) == 0 {
+		t.Fatalf("orchard=%+v", tx.Orchard)
+	}
+	if !bytes.Equal(tx.Serialize(), raw) {
+		t.Fatalf("round-trip mismatch")
+	}
+}
+
+func TestParseBlock_Regtest(t *testing.T) {
+	rawTx, raw, _ := readRegtestFixture(t)
+
+	block, err := junotx.ParseBlock(raw)
+	if err != nil {
+		t.Fatalf("ParseBlock: %v", err)
+	}
+	if len(block.Transactions) < 2 || !block.Transactions[0].IsCoinbase() {
+		t.Fatalf("txs=%d", len(block.Transactions))
+	}
+	found := false
+	for _, tx := range block.Transactions[1:] {
+		found = found || bytes.Equal(tx.Serialize(), rawTx)
+	}
+	if !found {
+		t.Fatalf("captured transaction not in block")
+	}
+	if !bytes.Equal(block.Serialize(), raw) {
+		t.Fatalf("round-trip mismatch")
+	}
+}
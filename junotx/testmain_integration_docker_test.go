@@ -0,0 +1,42 @@
+//go:build integration && docker
+
+package junotx_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Abdullah1738/juno-sdk-go/junoregtest"
+)
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	node, err := junoregtest.StartDocker(ctx, junoregtest.DockerConfig{})
+	if err != nil {
+		log.Printf("start junocashd container: %v", err)
+		os.Exit(1)
+	}
+
+	os.Setenv("JUNO_TEST_DOCKER", "1")
+	os.Setenv("JUNO_TEST_RPC_URL", node.RPCURL)
+	os.Setenv("JUNO_TEST_RPC_USER", node.RPCUser)
+	os.Setenv("JUNO_TEST_RPC_PASS", node.RPCPassword)
+	os.Setenv("JUNO_TEST_JUNOCASHD_CONTAINER", node.ContainerID)
+	os.Setenv("JUNO_TEST_JUNOCASHD_DATADIR", node.Datadir)
+	os.Setenv("JUNO_TEST_JUNOCASHD_RPC_PORT", "8232")
+
+	code := m.Run()
+
+	termCtx, termCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer termCancel()
+	if err := node.Stop(termCtx); err != nil {
+		log.Printf("terminate container: %v", err)
+	}
+
+	os.Exit(code)
+}
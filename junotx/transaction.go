@@ -0,0 +1,429 @@
+// Package junotx parses and serializes v5 (ZIP-225) transactions and blocks.
+package junotx
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	TxVersion5        uint32 = 5
+	TxVersionGroupID5 uint32 = 0x26A7270A
+
+	overwinteredFlag uint32 = 1 << 31
+
+	EncCiphertextSize = 580
+	OutCiphertextSize = 80
+	GrothProofSize    = 192
+	SignatureSize     = 64
+)
+
+var ErrUnsupportedVersion = errors.New("junotx: unsupported transaction version")
+
+type OutPoint struct {
+	// Hash is the txid in internal (little-endian) byte order.
+	Hash  [32]byte
+	Index uint32
+}
+
+type TxIn struct {
+	PrevOut   OutPoint
+	ScriptSig []byte
+	Sequence  uint32
+}
+
+type TxOut struct {
+	Value        int64
+	ScriptPubKey []byte
+}
+
+type SaplingSpend struct {
+	CV        [32]byte
+	Nullifier [32]byte
+	RK        [32]byte
+	Proof     [GrothProofSize]byte
+	AuthSig   [SignatureSize]byte
+}
+
+type SaplingOutput struct {
+	CV            [32]byte
+	CMU           [32]byte
+	EphemeralKey  [32]byte
+	EncCiphertext [EncCiphertextSize]byte
+	OutCiphertext [OutCiphertextSize]byte
+	Proof         [GrothProofSize]byte
+}
+
+type SaplingBundle struct {
+	Spends       []SaplingSpend
+	Outputs      []SaplingOutput
+	ValueBalance int64
+	Anchor       [32]byte
+	BindingSig   [SignatureSize]byte
+}
+
+type OrchardFlags byte
+
+const (
+	OrchardFlagEnableSpends  OrchardFlags = 1 << 0
+	OrchardFlagEnableOutputs OrchardFlags = 1 << 1
+)
+
+func (f OrchardFlags) SpendsEnabled() bool  { return f&OrchardFlagEnableSpends != 0 }
+func (f OrchardFlags) OutputsEnabled() bool { return f&OrchardFlagEnableOutputs != 0 }
+
+type OrchardAction struct {
+	CV            [32]byte
+	Nullifier     [32]byte
+	RK            [32]byte
+	CMX           [32]byte
+	EphemeralKey  [32]byte
+	EncCiphertext [EncCiphertextSize]byte
+	OutCiphertext [OutCiphertextSize]byte
+	SpendAuthSig  [SignatureSize]byte
+}
+
+type OrchardBundle struct {
+	Actions      []OrchardAction
+	Flags        OrchardFlags
+	ValueBalance int64
+	Anchor       [32]byte
+	Proof        []byte
+	BindingSig   [SignatureSize]byte
+}
+
+type Transaction struct {
+	Version           uint32
+	VersionGroupID    uint32
+	ConsensusBranchID uint32
+	LockTime          uint32
+	ExpiryHeight      uint32
+
+	TransparentInputs  []TxIn
+	TransparentOutputs []TxOut
+
+	// Sapling and Orchard are nil when the transaction has no actions in that pool.
+	Sapling *SaplingBundle
+	Orchard *OrchardBundle
+}
+
+// ParseTransaction parses a complete v5 transaction. Trailing bytes are an error.
+func ParseTransaction(b []byte) (*Transaction, error) {
+	r := &reader{buf: b}
+	tx, err := readTransaction(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.remaining() != 0 {
+		return nil, fmt.Errorf("junotx: %d trailing bytes after transaction", r.remaining())
+	}
+	return tx, nil
+}
+
+// ParseTransactionHex parses the hex form returned by getrawtransaction.
+func ParseTransactionHex(s string) (*Transaction, error) {
+	b, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("junotx: invalid hex: %w", err)
+	}
+	return ParseTransaction(b)
+}
+
+func (tx *Transaction) IsCoinbase() bool {
+	return len(tx.TransparentInputs) == 1 &&
+		tx.TransparentInputs[0].PrevOut.Hash == [32]byte{} &&
+		tx.TransparentInputs[0].PrevOut.Index == 0xffffffff
+}
+
+// Serialize returns the consensus encoding of tx.
+func (tx *Transaction) Serialize() []byte {
+	w := &writer{}
+	tx.write(w)
+	return w.buf
+}
+
+func readTransaction(r *reader) (*Transaction, error) {
+	header, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if header&overwinteredFlag == 0 || header&^overwinteredFlag != TxVersion5 {
+		return nil, fmt.Errorf("%w: header 0x%08x", ErrUnsupportedVersion, header)
+	}
+
+	tx := &Transaction{Version: header &^ overwinteredFlag}
+	if tx.VersionGroupID, err = r.uint32(); err != nil {
+		return nil, err
+	}
+	if tx.VersionGroupID != TxVersionGroupID5 {
+		return nil, fmt.Errorf("%w: version group id 0x%08x", ErrUnsupportedVersion, tx.VersionGroupID)
+	}
+	if tx.ConsensusBranchID, err = r.uint32(); err != nil {
+		return nil, err
+	}
+	if tx.LockTime, err = r.uint32(); err != nil {
+		return nil, err
+	}
+	if tx.ExpiryHeight, err = r.uint32(); err != nil {
+		return nil, err
+	}
+
+	if err := readTransparent(r, tx); err != nil {
+		return nil, err
+	}
+	if tx.Sapling, err = readSapling(r); err != nil {
+		return nil, err
+	}
+	if tx.Orchard, err = readOrchard(r); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func readTransparent(r *reader, tx *Transaction) error {
+	nIn, err := r.count(41)
+	if err != nil {
+		return err
+	}
+	if nIn > 0 {
+		tx.TransparentInputs = make([]TxIn, nIn)
+	}
+	for i := range tx.TransparentInputs {
+		in := &tx.TransparentInputs[i]
+		if err := r.copyInto(in.PrevOut.Hash[:]); err != nil {
+			return err
+		}
+		if in.PrevOut.Index, err = r.uint32(); err != nil {
+			return err
+		}
+		if in.ScriptSig, err = r.varBytes(); err != nil {
+			return err
+		}
+		if in.Sequence, err = r.uint32(); err != nil {
+			return err
+		}
+	}
+
+	nOut, err := r.count(9)
+	if err != nil {
+		return err
+	}
+	if nOut > 0 {
+		tx.TransparentOutputs = make([]TxOut, nOut)
+	}
+	for i := range tx.TransparentOutputs {
+		out := &tx.TransparentOutputs[i]
+		if out.Value, err = r.int64(); err != nil {
+			return err
+		}
+		if out.ScriptPubKey, err = r.varBytes(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readSapling(r *reader) (*SaplingBundle, error) {
+	nSpends, err := r.count(96)
+	if err != nil {
+		return nil, err
+	}
+	spends := make([]SaplingSpend, nSpends)
+	for i := range spends {
+		s := &spends[i]
+		for _, dst := range [][]byte{s.CV[:], s.Nullifier[:], s.RK[:]} {
+			if err := r.copyInto(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	nOutputs, err := r.count(756)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]SaplingOutput, nOutputs)
+	for i := range outputs {
+		o := &outputs[i]
+		for _, dst := range [][]byte{o.CV[:], o.CMU[:], o.EphemeralKey[:], o.EncCiphertext[:], o.OutCiphertext[:]} {
+			if err := r.copyInto(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if nSpends+nOutputs == 0 {
+		return nil, nil
+	}
+
+	b := &SaplingBundle{Spends: spends, Outputs: outputs}
+	if b.ValueBalance, err = r.int64(); err != nil {
+		return nil, err
+	}
+	if nSpends > 0 {
+		if err := r.copyInto(b.Anchor[:]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spends {
+		if err := r.copyInto(spends[i].Proof[:]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spends {
+		if err := r.copyInto(spends[i].AuthSig[:]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range outputs {
+		if err := r.copyInto(outputs[i].Proof[:]); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.copyInto(b.BindingSig[:]); err != nil {
+		return nil, err
+	}
+	if len(b.Spends) == 0 {
+		b.Spends = nil
+	}
+	if len(b.Outputs) == 0 {
+		b.Outputs = nil
+	}
+	return b, nil
+}
+
+func readOrchard(r *reader) (*OrchardBundle, error) {
+	nActions, err := r.count(820)
+	if err != nil {
+		return nil, err
+	}
+	if nActions == 0 {
+		return nil, nil
+	}
+
+	b := &OrchardBundle{Actions: make([]OrchardAction, nActions)}
+	for i := range b.Actions {
+		a := &b.Actions[i]
+		for _, dst := range [][]byte{a.CV[:], a.Nullifier[:], a.RK[:], a.CMX[:], a.EphemeralKey[:], a.EncCiphertext[:], a.OutCiphertext[:]} {
+			if err := r.copyInto(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	flags, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	b.Flags = OrchardFlags(flags)
+	if b.Flags&^(OrchardFlagEnableSpends|OrchardFlagEnableOutputs) != 0 {
+		return nil, fmt.Errorf("junotx: invalid orchard flags 0x%02x", flags)
+	}
+	if b.ValueBalance, err = r.int64(); err != nil {
+		return nil, err
+	}
+	if err := r.copyInto(b.Anchor[:]); err != nil {
+		return nil, err
+	}
+	if b.Proof, err = r.varBytes(); err != nil {
+		return nil, err
+	}
+	for i := range b.Actions {
+		if err := r.copyInto(b.Actions[i].SpendAuthSig[:]); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.copyInto(b.BindingSig[:]); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (tx *Transaction) write(w *writer) {
+	w.uint32(tx.Version | overwinteredFlag)
+	w.uint32(tx.VersionGroupID)
+	w.uint32(tx.ConsensusBranchID)
+	w.uint32(tx.LockTime)
+	w.uint32(tx.ExpiryHeight)
+
+	w.compactSize(uint64(len(tx.TransparentInputs)))
+	for _, in := range tx.TransparentInputs {
+		w.write(in.PrevOut.Hash[:])
+		w.uint32(in.PrevOut.Index)
+		w.varBytes(in.ScriptSig)
+		w.uint32(in.Sequence)
+	}
+	w.compactSize(uint64(len(tx.TransparentOutputs)))
+	for _, out := range tx.TransparentOutputs {
+		w.int64(out.Value)
+		w.varBytes(out.ScriptPubKey)
+	}
+
+	writeSapling(w, tx.Sapling)
+	writeOrchard(w, tx.Orchard)
+}
+
+func writeSapling(w *writer, b *SaplingBundle) {
+	if b == nil || len(b.Spends)+len(b.Outputs) == 0 {
+		w.compactSize(0)
+		w.compactSize(0)
+		return
+	}
+
+	w.compactSize(uint64(len(b.Spends)))
+	for _, s := range b.Spends {
+		w.write(s.CV[:])
+		w.write(s.Nullifier[:])
+		w.write(s.RK[:])
+	}
+	w.compactSize(uint64(len(b.Outputs)))
+	for _, o := range b.Outputs {
+		w.write(o.CV[:])
+		w.write(o.CMU[:])
+		w.write(o.EphemeralKey[:])
+		w.write(o.EncCiphertext[:])
+		w.write(o.OutCiphertext[:])
+	}
+	w.int64(b.ValueBalance)
+	if len(b.Spends) > 0 {
+		w.write(b.Anchor[:])
+	}
+	for _, s := range b.Spends {
+		w.write(s.Proof[:])
+	}
+	for _, s := range b.Spends {
+		w.write(s.AuthSig[:])
+	}
+	for _, o := range b.Outputs {
+		w.write(o.Proof[:])
+	}
+	w.write(b.BindingSig[:])
+}
+
+func writeOrchard(w *writer, b *OrchardBundle) {
+	if b == nil || len(b.Actions) == 0 {
+		w.compactSize(0)
+		return
+	}
+
+	w.compactSize(uint64(len(b.Actions)))
+	for _, a := range b.Actions {
+		w.write(a.CV[:])
+		w.write(a.Nullifier[:])
+		w.write(a.RK[:])
+		w.write(a.CMX[:])
+		w.write(a.EphemeralKey[:])
+		w.write(a.EncCiphertext[:])
+		w.write(a.OutCiphertext[:])
+	}
+	w.byte(byte(b.Flags))
+	w.int64(b.ValueBalance)
+	w.write(b.Anchor[:])
+	w.varBytes(b.Proof)
+	for _, a := range b.Actions {
+		w.write(a.SpendAuthSig[:])
+	}
+	w.write(b.BindingSig[:])
+}
@@ -0,0 +1,151 @@
+package junotx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+)
+
+// readFixture reads a hex fixture from testdata. coinbase_v5.hex, orchard_v5.hex and
+// block_v5.hex are hand-built: they exercise every field of the encoding but their field
+// elements, points and value balances are not valid. The regtest_* fixtures are real.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	b, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	return b
+}
+
+func TestParseTransaction_Coinbase(t *testing.T) {
+	raw := readFixture(t, "coinbase_v5.hex")
+
+	tx, err := junotx.ParseTransaction(raw)
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	if tx.Version != junotx.TxVersion5 || tx.VersionGroupID != junotx.TxVersionGroupID5 {
+		t.Fatalf("version=%d group=%08x", tx.Version, tx.VersionGroupID)
+	}
+	if tx.ConsensusBranchID != 0x4dec4df0 {
+		t.Fatalf("branch=%08x", tx.ConsensusBranchID)
+	}
+	if tx.ExpiryHeight != 121 {
+		t.Fatalf("expiry=%d", tx.ExpiryHeight)
+	}
+	if !tx.IsCoinbase() {
+		t.Fatalf("expected coinbase")
+	}
+	if len(tx.TransparentOutputs) != 2 || tx.TransparentOutputs[0].Value != 312500000 {
+		t.Fatalf("outputs=%+v", tx.TransparentOutputs)
+	}
+	if tx.Sapling != nil || tx.Orchard != nil {
+		t.Fatalf("expected no shielded bundles")
+	}
+	if got := tx.Serialize(); !bytes.Equal(got, raw) {
+		t.Fatalf("round-trip mismatch:\n got=%x\nwant=%x", got, raw)
+	}
+}
+
+func TestParseTransaction_Orchard(t *testing.T) {
+	raw := readFixture(t, "orchard_v5.hex")
+
+	tx, err := junotx.ParseTransactionHex(hex.EncodeToString(raw))
+	if err != nil {
+		t.Fatalf("ParseTransactionHex: %v", err)
+	}
+	if tx.IsCoinbase() || len(tx.TransparentInputs) != 0 || len(tx.TransparentOutputs) != 0 {
+		t.Fatalf("unexpected transparent bundle")
+	}
+	if tx.ExpiryHeight != 140 {
+		t.Fatalf("expiry=%d", tx.ExpiryHeight)
+	}
+
+	o := tx.Orchard
+	if o == nil || len(o.Actions) != 2 {
+		t.Fatalf("orchard=%+v", o)
+	}
+	if !o.Flags.SpendsEnabled() || !o.Flags.OutputsEnabled() {
+		t.Fatalf("flags=%02x", o.Flags)
+	}
+	if o.ValueBalance != -10000 {
+		t.Fatalf("value balance=%d", o.ValueBalance)
+	}
+	if len(o.Proof) != 2720+2272*2 {
+		t.Fatalf("proof len=%d", len(o.Proof))
+	}
+	if o.Actions[0].Nullifier == o.Actions[1].Nullifier {
+		t.Fatalf("nullifiers should differ")
+	}
+	if got := tx.Serialize(); !bytes.Equal(got, raw) {
+		t.Fatalf("round-trip mismatch")
+	}
+}
+
+func TestParseTransaction_Sapling(t *testing.T) {
+	in := &junotx.Transaction{
+		Version:           junotx.TxVersion5,
+		VersionGroupID:    junotx.TxVersionGroupID5,
+		ConsensusBranchID: 0x4dec4df0,
+		ExpiryHeight:      10,
+		Sapling: &junotx.SaplingBundle{
+			Spends:       []junotx.SaplingSpend{{CV: [32]byte{1}, Nullifier: [32]byte{2}, RK: [32]byte{3}, Proof: [192]byte{4}, AuthSig: [64]byte{5}}},
+			Outputs:      []junotx.SaplingOutput{{CV: [32]byte{6}, CMU: [32]byte{7}, EphemeralKey: [32]byte{8}, Proof: [192]byte{9}}},
+			ValueBalance: 1000,
+			Anchor:       [32]byte{10},
+			BindingSig:   [64]byte{11},
+		},
+	}
+
+	raw := in.Serialize()
+	out, err := junotx.ParseTransaction(raw)
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	if out.Sapling == nil || len(out.Sapling.Spends) != 1 || len(out.Sapling.Outputs) != 1 {
+		t.Fatalf("sapling=%+v", out.Sapling)
+	}
+	if out.Sapling.Spends[0].AuthSig[0] != 5 || out.Sapling.Outputs[0].Proof[0] != 9 || out.Sapling.Anchor[0] != 10 {
+		t.Fatalf("sapling fields not preserved")
+	}
+	if !bytes.Equal(out.Serialize(), raw) {
+		t.Fatalf("round-trip mismatch")
+	}
+}
+
+func TestParseTransaction_Errors(t *testing.T) {
+	raw := readFixture(t, "orchard_v5.hex")
+
+	if _, err := junotx.ParseTransaction(raw[:len(raw)-1]); !errors.Is(err, junotx.ErrTruncated) {
+		t.Fatalf("truncated: err=%v", err)
+	}
+	if _, err := junotx.ParseTransaction(append(append([]byte(nil), raw...), 0)); err == nil {
+		t.Fatalf("expected trailing bytes error")
+	}
+
+	v4 := append([]byte(nil), raw...)
+	v4[0] = 4
+	if _, err := junotx.ParseTransaction(v4); !errors.Is(err, junotx.ErrUnsupportedVersion) {
+		t.Fatalf("v4: err=%v", err)
+	}
+
+	// Orchard flags live right after the actions; set an undefined bit.
+	badFlags := append([]byte(nil), raw...)
+	flagsOffset := 20 + 4 + 1 + 2*820
+	badFlags[flagsOffset] |= 0x80
+	if _, err := junotx.ParseTransaction(badFlags); err == nil {
+		t.Fatalf("expected invalid flags error")
+	}
+}
@@ -0,0 +1,285 @@
+package junotx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Abdullah1738/juno-sdk-go/internal/blake2b"
+)
+
+// ZIP-244 personalization strings. junocashd keeps the zcashd prefixes; what makes the
+// digests Juno-specific is the little-endian consensus branch id the top-level digests
+// append to the 12-byte prefix (types.ConsensusBranchID). The digests are tested against the
+// published ZIP-244 vectors and against the txids and auth digests junocashd reports.
+const (
+	TxIDPersonalizationPrefix       = "ZcashTxHash_"
+	AuthDigestPersonalizationPrefix = "ZTxAuthHash_"
+
+	SighashAll byte = 0x01
+
+	encCiphertextCompactSize = 52
+	encCiphertextMemoEnd     = 564
+)
+
+// TxIDDigest returns the ZIP-244 txid digest in internal byte order.
+func (tx *Transaction) TxIDDigest() [32]byte {
+	return digest32(branchPersonal(TxIDPersonalizationPrefix, tx.ConsensusBranchID),
+		tx.headerDigest(),
+		tx.transparentDigest(),
+		tx.saplingDigest(),
+		tx.orchardDigest(),
+	)
+}
+
+// TxID returns the txid in the byte-reversed hex form used by the node RPC.
+func (tx *Transaction) TxID() string {
+	return HashToHex(tx.TxIDDigest())
+}
+
+// AuthDigest returns the ZIP-244 authorizing data commitment in internal byte order.
+func (tx *Transaction) AuthDigest() [32]byte {
+	return digest32(branchPersonal(AuthDigestPersonalizationPrefix, tx.ConsensusBranchID),
+		tx.transparentAuthDigest(),
+		tx.saplingAuthDigest(),
+		tx.orchardAuthDigest(),
+	)
+}
+
+// AuthDigestHex returns the auth digest in the byte-reversed hex form used by the node RPC.
+func (tx *Transaction) AuthDigestHex() string {
+	return HashToHex(tx.AuthDigest())
+}
+
+// SignatureDigest returns the ZIP-244 SIGHASH_ALL digest signed by Sapling and Orchard
+// spend authorizations and binding signatures. prevOuts must hold the outputs spent by
+// each transparent input, in input order; it is ignored for coinbase transactions and
+// transactions without transparent inputs, whose signature digest equals the txid digest.
+func (tx *Transaction) SignatureDigest(prevOuts []TxOut) ([32]byte, error) {
+	transparent := tx.transparentDigest()
+	if len(tx.TransparentInputs) > 0 && !tx.IsCoinbase() {
+		if len(prevOuts) != len(tx.TransparentInputs) {
+			return [32]byte{}, fmt.Errorf("junotx: %d prevouts for %d transparent inputs", len(prevOuts), len(tx.TransparentInputs))
+		}
+		transparent = tx.transparentSigDigest(prevOuts)
+	}
+	return digest32(branchPersonal(TxIDPersonalizationPrefix, tx.ConsensusBranchID),
+		tx.headerDigest(),
+		transparent,
+		tx.saplingDigest(),
+		tx.orchardDigest(),
+	), nil
+}
+
+// VerifyTxID reports whether txid (RPC hex form) matches the digest computed from tx.
+func (tx *Transaction) VerifyTxID(txid string) error {
+	want, err := HashFromHex(txid)
+	if err != nil {
+		return err
+	}
+	if tx.TxIDDigest() != want {
+		return errors.New("junotx: txid mismatch")
+	}
+	return nil
+}
+
+func (tx *Transaction) headerDigest() []byte {
+	w := &writer{}
+	w.uint32(tx.Version | overwinteredFlag)
+	w.uint32(tx.VersionGroupID)
+	w.uint32(tx.ConsensusBranchID)
+	w.uint32(tx.LockTime)
+	w.uint32(tx.ExpiryHeight)
+	return digestSlice("ZTxIdHeadersHash", w.buf)
+}
+
+func (tx *Transaction) transparentDigest() []byte {
+	if len(tx.TransparentInputs) == 0 && len(tx.TransparentOutputs) == 0 {
+		return digestSlice("ZTxIdTranspaHash")
+	}
+	return digestSlice("ZTxIdTranspaHash",
+		tx.prevoutsDigest(),
+		tx.sequenceDigest(),
+		tx.outputsDigest(),
+	)
+}
+
+func (tx *Transaction) transparentSigDigest(prevOuts []TxOut) []byte {
+	amounts := &writer{}
+	scripts := &writer{}
+	for _, out := range prevOuts {
+		amounts.int64(out.Value)
+		scripts.varBytes(out.ScriptPubKey)
+	}
+	return digestSlice("ZTxIdTranspaHash",
+		[]byte{SighashAll},
+		tx.prevoutsDigest(),
+		digestSlice("ZTxTrAmountsHash", amounts.buf),
+		digestSlice("ZTxTrScriptsHash", scripts.buf),
+		tx.sequenceDigest(),
+		tx.outputsDigest(),
+		digestSlice("Zcash___TxInHash"),
+	)
+}
+
+func (tx *Transaction) prevoutsDigest() []byte {
+	w := &writer{}
+	for _, in := range tx.TransparentInputs {
+		w.write(in.PrevOut.Hash[:])
+		w.uint32(in.PrevOut.Index)
+	}
+	return digestSlice("ZTxIdPrevoutHash", w.buf)
+}
+
+func (tx *Transaction) sequenceDigest() []byte {
+	w := &writer{}
+	for _, in := range tx.TransparentInputs {
+		w.uint32(in.Sequence)
+	}
+	return digestSlice("ZTxIdSequencHash", w.buf)
+}
+
+func (tx *Transaction) outputsDigest() []byte {
+	w := &writer{}
+	for _, out := range tx.TransparentOutputs {
+		w.int64(out.Value)
+		w.varBytes(out.ScriptPubKey)
+	}
+	return digestSlice("ZTxIdOutputsHash", w.buf)
+}
+
+func (tx *Transaction) saplingDigest() []byte {
+	b := tx.Sapling
+	if b == nil || len(b.Spends)+len(b.Outputs) == 0 {
+		return digestSlice("ZTxIdSaplingHash")
+	}
+
+	spends := digestSlice("ZTxIdSSpendsHash")
+	if len(b.Spends) > 0 {
+		compact := &writer{}
+		noncompact := &writer{}
+		for _, s := range b.Spends {
+			compact.write(s.Nullifier[:])
+			noncompact.write(s.CV[:])
+			noncompact.write(b.Anchor[:])
+			noncompact.write(s.RK[:])
+		}
+		spends = digestSlice("ZTxIdSSpendsHash",
+			digestSlice("ZTxIdSSpendCHash", compact.buf),
+			digestSlice("ZTxIdSSpendNHash", noncompact.buf),
+		)
+	}
+
+	outputs := digestSlice("ZTxIdSOutputHash")
+	if len(b.Outputs) > 0 {
+		compact := &writer{}
+		memos := &writer{}
+		noncompact := &writer{}
+		for _, o := range b.Outputs {
+			compact.write(o.CMU[:])
+			compact.write(o.EphemeralKey[:])
+			compact.write(o.EncCiphertext[:encCiphertextCompactSize])
+			memos.write(o.EncCiphertext[encCiphertextCompactSize:encCiphertextMemoEnd])
+			noncompact.write(o.CV[:])
+			noncompact.write(o.EncCiphertext[encCiphertextMemoEnd:])
+			noncompact.write(o.OutCiphertext[:])
+		}
+		outputs = digestSlice("ZTxIdSOutputHash",
+			digestSlice("ZTxIdSOutC__Hash", compact.buf),
+			digestSlice("ZTxIdSOutM__Hash", memos.buf),
+			digestSlice("ZTxIdSOutN__Hash", noncompact.buf),
+		)
+	}
+
+	w := &writer{}
+	w.int64(b.ValueBalance)
+	return digestSlice("ZTxIdSaplingHash", spends, outputs, w.buf)
+}
+
+func (tx *Transaction) orchardDigest() []byte {
+	b := tx.Orchard
+	if b == nil || len(b.Actions) == 0 {
+		return digestSlice("ZTxIdOrchardHash")
+	}
+
+	compact := &writer{}
+	memos := &writer{}
+	noncompact := &writer{}
+	for _, a := range b.Actions {
+		compact.write(a.Nullifier[:])
+		compact.write(a.CMX[:])
+		compact.write(a.EphemeralKey[:])
+		compact.write(a.EncCiphertext[:encCiphertextCompactSize])
+		memos.write(a.EncCiphertext[encCiphertextCompactSize:encCiphertextMemoEnd])
+		noncompact.write(a.CV[:])
+		noncompact.write(a.RK[:])
+		noncompact.write(a.EncCiphertext[encCiphertextMemoEnd:])
+		noncompact.write(a.OutCiphertext[:])
+	}
+
+	w := &writer{}
+	w.byte(byte(b.Flags))
+	w.int64(b.ValueBalance)
+	w.write(b.Anchor[:])
+	return digestSlice("ZTxIdOrchardHash",
+		digestSlice("ZTxIdOrcActCHash", compact.buf),
+		digestSlice("ZTxIdOrcActMHash", memos.buf),
+		digestSlice("ZTxIdOrcActNHash", noncompact.buf),
+		w.buf,
+	)
+}
+
+func (tx *Transaction) transparentAuthDigest() []byte {
+	w := &writer{}
+	for _, in := range tx.TransparentInputs {
+		w.varBytes(in.ScriptSig)
+	}
+	return digestSlice("ZTxAuthTransHash", w.buf)
+}
+
+func (tx *Transaction) saplingAuthDigest() []byte {
+	b := tx.Sapling
+	if b == nil || len(b.Spends)+len(b.Outputs) == 0 {
+		return digestSlice("ZTxAuthSapliHash")
+	}
+	w := &writer{}
+	for _, s := range b.Spends {
+		w.write(s.Proof[:])
+	}
+	for _, s := range b.Spends {
+		w.write(s.AuthSig[:])
+	}
+	for _, o := range b.Outputs {
+		w.write(o.Proof[:])
+	}
+	w.write(b.BindingSig[:])
+	return digestSlice("ZTxAuthSapliHash", w.buf)
+}
+
+func (tx *Transaction) orchardAuthDigest() []byte {
+	b := tx.Orchard
+	if b == nil || len(b.Actions) == 0 {
+		return digestSlice("ZTxAuthOrchaHash")
+	}
+	w := &writer{}
+	w.write(b.Proof)
+	for _, a := range b.Actions {
+		w.write(a.SpendAuthSig[:])
+	}
+	w.write(b.BindingSig[:])
+	return digestSlice("ZTxAuthOrchaHash", w.buf)
+}
+
+func branchPersonal(prefix string, branchID uint32) []byte {
+	return binary.LittleEndian.AppendUint32([]byte(prefix), branchID)
+}
+
+func digestSlice(personal string, parts ...[]byte) []byte {
+	return blake2b.Sum(32, []byte(personal), parts...)
+}
+
+func digest32(personal []byte, parts ...[]byte) [32]byte {
+	var out [32]byte
+	copy(out[:], blake2b.Sum(32, personal, parts...))
+	return out
+}
@@ -0,0 +1,183 @@
+package junotx_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+)
+
+// zip244Vector is one row of testdata/zip_0244.json, the published ZIP-244 test vectors from
+// https://github.com/zcash/zcash-test-vectors. Digests are in internal byte order.
+type zip244Vector struct {
+	Tx              string
+	TxID            string
+	AuthDigest      string
+	Amounts         []int64
+	ScriptPubKeys   []string
+	SighashShielded string
+}
+
+func readZIP244Vectors(t *testing.T) []zip244Vector {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", "zip_0244.json"))
+	if err != nil {
+		t.Fatalf("read vectors: %v", err)
+	}
+	var rows [][]json.RawMessage
+	if err := json.Unmarshal(b, &rows); err != nil {
+		t.Fatalf("decode vectors: %v", err)
+	}
+	// The first two rows are the source and the column names.
+	var out []zip244Vector
+	for i, row := range rows[2:] {
+		var v zip244Vector
+		for j, dst := range []any{&v.Tx, &v.TxID, &v.AuthDigest, &v.Amounts, &v.ScriptPubKeys, nil, &v.SighashShielded} {
+			if dst == nil {
+				continue
+			}
+			if err := json.Unmarshal(row[j], dst); err != nil {
+				t.Fatalf("vector %d column %d: %v", i, j, err)
+			}
+		}
+		out = append(out, v)
+	}
+	if len(out) == 0 {
+		t.Fatalf("no vectors")
+	}
+	return out
+}
+
+func TestTransaction_ZIP244Vectors(t *testing.T) {
+	for i, v := range readZIP244Vectors(t) {
+		raw, err := hex.DecodeString(v.Tx)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		tx, err := junotx.ParseTransaction(raw)
+		if err != nil {
+			t.Fatalf("vector %d: ParseTransaction: %v", i, err)
+		}
+		if got := hex.EncodeToString(tx.Serialize()); got != v.Tx {
+			t.Fatalf("vector %d: round-trip mismatch", i)
+		}
+		if got := tx.TxIDDigest(); hex.EncodeToString(got[:]) != v.TxID {
+			t.Fatalf("vector %d: txid=%x want %s", i, got, v.TxID)
+		}
+		if got := tx.AuthDigest(); hex.EncodeToString(got[:]) != v.AuthDigest {
+			t.Fatalf("vector %d: auth digest=%x want %s", i, got, v.AuthDigest)
+		}
+
+		var prevOuts []junotx.TxOut
+		for j, amount := range v.Amounts {
+			script, err := hex.DecodeString(v.ScriptPubKeys[j])
+			if err != nil {
+				t.Fatalf("vector %d: script %d: %v", i, j, err)
+			}
+			prevOuts = append(prevOuts, junotx.TxOut{Value: amount, ScriptPubKey: script})
+		}
+		got, err := tx.SignatureDigest(prevOuts)
+		if err != nil {
+			t.Fatalf("vector %d: SignatureDigest: %v", i, err)
+		}
+		if hex.EncodeToString(got[:]) != v.SighashShielded {
+			t.Fatalf("vector %d (%d transparent inputs): sighash=%x want %s", i, len(tx.TransparentInputs), got, v.SighashShielded)
+		}
+	}
+}
+
+func TestTransaction_TxIDMatchesRegtestNode(t *testing.T) {
+	raw, _, meta := readRegtestFixture(t)
+
+	tx, err := junotx.ParseTransaction(raw)
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	if got := tx.TxID(); got != meta.TxID {
+		t.Fatalf("txid=%s want %s", got, meta.TxID)
+	}
+	if got := tx.AuthDigestHex(); got != meta.AuthDigest {
+		t.Fatalf("auth digest=%s want %s", got, meta.AuthDigest)
+	}
+	if err := tx.VerifyTxID(meta.TxID); err != nil {
+		t.Fatalf("VerifyTxID: %v", err)
+	}
+}
+
+func TestTransaction_TxIDIgnoresAuthorizingData(t *testing.T) {
+	tx, err := junotx.ParseTransaction(readFixture(t, "orchard_v5.hex"))
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	txid := tx.TxIDDigest()
+	auth := tx.AuthDigest()
+
+	tx.Orchard.Proof[0] ^= 1
+	tx.Orchard.Actions[1].SpendAuthSig[0] ^= 1
+	tx.Orchard.BindingSig[0] ^= 1
+	if tx.TxIDDigest() != txid {
+		t.Fatalf("txid must not commit to proofs or signatures")
+	}
+	if tx.AuthDigest() == auth {
+		t.Fatalf("auth digest must commit to proofs and signatures")
+	}
+
+	tx.Orchard.Actions[0].Nullifier[0] ^= 1
+	if tx.TxIDDigest() == txid {
+		t.Fatalf("txid must commit to nullifiers")
+	}
+	if err := tx.VerifyTxID(junotx.HashToHex(txid)); err == nil {
+		t.Fatalf("expected txid mismatch")
+	}
+}
+
+func TestTransaction_BranchIDIsPersonalization(t *testing.T) {
+	tx, err := junotx.ParseTransaction(readFixture(t, "orchard_v5.hex"))
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	txid := tx.TxIDDigest()
+	tx.ConsensusBranchID ^= 1
+	if tx.TxIDDigest() == txid {
+		t.Fatalf("txid must depend on the consensus branch id")
+	}
+}
+
+func TestTransaction_SignatureDigest(t *testing.T) {
+	shielded, err := junotx.ParseTransaction(readFixture(t, "orchard_v5.hex"))
+	if err != nil {
+		t.Fatalf("ParseTransaction: %v", err)
+	}
+	got, err := shielded.SignatureDigest(nil)
+	if err != nil {
+		t.Fatalf("SignatureDigest: %v", err)
+	}
+	if got != shielded.TxIDDigest() {
+		t.Fatalf("shielded-only signature digest must equal the txid digest")
+	}
+
+	spending := *shielded
+	spending.TransparentInputs = []junotx.TxIn{{PrevOut: junotx.OutPoint{Hash: [32]byte{1}, Index: 0}, Sequence: 0xffffffff}}
+	if _, err := spending.SignatureDigest(nil); err == nil {
+		t.Fatalf("expected error without prevouts")
+	}
+	prevOuts := []junotx.TxOut{{Value: 5000, ScriptPubKey: []byte{0x51}}}
+	sig1, err := spending.SignatureDigest(prevOuts)
+	if err != nil {
+		t.Fatalf("SignatureDigest: %v", err)
+	}
+	if sig1 == spending.TxIDDigest() {
+		t.Fatalf("transparent signature digest must differ from the txid digest")
+	}
+	prevOuts[0].Value++
+	sig2, err := spending.SignatureDigest(prevOuts)
+	if err != nil {
+		t.Fatalf("SignatureDigest: %v", err)
+	}
+	if sig1 == sig2 {
+		t.Fatalf("signature digest must commit to prevout amounts")
+	}
+}
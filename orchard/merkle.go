@@ -0,0 +1,175 @@
+// Package orchard implements the Orchard note commitment tree hash (MerkleCRH^Orchard,
+// Sinsemilla over Pallas) so that witnesses returned by juno-scan can be checked locally
+// before a plan is handed to a prover.
+//
+// Nodes, roots and note commitments (cmx) are 32-byte little-endian encodings of Pallas base
+// field elements, hex-encoded in that byte order where strings are used, as in transactions
+// and juno-scan responses.
+package orchard
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+)
+
+// MerkleDepth is the depth of the Orchard note commitment tree.
+const MerkleDepth = 32
+
+var (
+	// ErrRootMismatch is returned by VerifyWitness when the auth path does not hash to root.
+	ErrRootMismatch = errors.New("orchard: auth path does not hash to root")
+	// ErrNonCanonical is returned for a node that is not the canonical encoding of a Pallas
+	// base field element.
+	ErrNonCanonical = errors.New("orchard: non-canonical field element")
+)
+
+// uncommitted is Uncommitted^Orchard, the value of an empty leaf.
+var uncommitted = [32]byte{2}
+
+var emptyRoots struct {
+	once  sync.Once
+	roots [MerkleDepth + 1][32]byte
+}
+
+// MerkleHash returns MerkleCRH^Orchard of two sibling nodes at the given altitude, where
+// leaves have altitude 0.
+func MerkleHash(altitude uint8, left, right [32]byte) ([32]byte, error) {
+	if altitude >= MerkleDepth {
+		return [32]byte{}, fmt.Errorf("orchard: altitude %d out of range", altitude)
+	}
+	l, err := decodeNode("left", left)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	r, err := decodeNode("right", right)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return merkleHash(altitude, l, r), nil
+}
+
+func merkleHash(altitude uint8, left, right *big.Int) [32]byte {
+	bits := make([]uint8, 0, 10+2*255)
+	bits = appendLEBits(bits, big.NewInt(int64(altitude)), 10)
+	bits = appendLEBits(bits, left, 255)
+	bits = appendLEBits(bits, right, 255)
+
+	sinsemillaInit()
+	x, ok := sinsemillaHash(sinsemillaTables.merkleQ, bits)
+	if !ok {
+		// MerkleCRH^Orchard maps ⊥ to 0.
+		return [32]byte{}
+	}
+	return encodeNode(x)
+}
+
+// EmptyRoot returns the root of an empty subtree of the given altitude; EmptyRoot(0) is the
+// empty leaf and EmptyRoot(MerkleDepth) the root of the empty tree. It panics if altitude
+// exceeds MerkleDepth.
+func EmptyRoot(altitude uint8) [32]byte {
+	emptyRoots.once.Do(func() {
+		emptyRoots.roots[0] = uncommitted
+		for h := range uint8(MerkleDepth) {
+			n := new(big.Int).SetBytes(reversed(emptyRoots.roots[h]))
+			emptyRoots.roots[h+1] = merkleHash(h, n, n)
+		}
+	})
+	return emptyRoots.roots[altitude]
+}
+
+// Root returns the root of the tree in which cmx is the leaf at position and authPath holds
+// its siblings from the leaf level up.
+func Root(cmx [32]byte, position uint32, authPath [][32]byte) ([32]byte, error) {
+	if len(authPath) != MerkleDepth {
+		return [32]byte{}, fmt.Errorf("orchard: auth path has %d nodes, want %d", len(authPath), MerkleDepth)
+	}
+	node, err := decodeNode("cmx", cmx)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	for h, sibling := range authPath {
+		s, err := decodeNode(fmt.Sprintf("auth path[%d]", h), sibling)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		var next [32]byte
+		if position>>h&1 == 0 {
+			next = merkleHash(uint8(h), node, s)
+		} else {
+			next = merkleHash(uint8(h), s, node)
+		}
+		node = new(big.Int).SetBytes(reversed(next))
+	}
+	return encodeNode(node), nil
+}
+
+// VerifyWitness checks that the hex-encoded authPath of the note commitment cmx at position
+// hashes to root. It returns an error wrapping ErrRootMismatch if it does not.
+func VerifyWitness(root, cmx string, position uint32, authPath []string) error {
+	want, err := parseNode("root", root)
+	if err != nil {
+		return err
+	}
+	leaf, err := parseNode("cmx", cmx)
+	if err != nil {
+		return err
+	}
+	path := make([][32]byte, len(authPath))
+	for i, s := range authPath {
+		if path[i], err = parseNode(fmt.Sprintf("auth path[%d]", i), s); err != nil {
+			return err
+		}
+	}
+	got, err := Root(leaf, position, path)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: position %d: computed %x, want %s", ErrRootMismatch, position, got, root)
+	}
+	return nil
+}
+
+func parseNode(field, s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return out, fmt.Errorf("orchard: %s: invalid hex", field)
+	}
+	if len(b) != len(out) {
+		return out, fmt.Errorf("orchard: %s: want 32 bytes, got %d", field, len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
+
+func decodeNode(field string, b [32]byte) (*big.Int, error) {
+	v := new(big.Int).SetBytes(reversed(b))
+	if v.Cmp(fieldP) >= 0 {
+		return nil, fmt.Errorf("%w: %s %x", ErrNonCanonical, field, b)
+	}
+	return v, nil
+}
+
+func encodeNode(v *big.Int) [32]byte {
+	var be [32]byte
+	v.FillBytes(be[:])
+	return [32]byte(reversed(be))
+}
+
+func reversed(b [32]byte) []byte {
+	out := b[:]
+	slices.Reverse(out)
+	return out
+}
+
+func appendLEBits(bits []uint8, v *big.Int, n int) []uint8 {
+	for i := range n {
+		bits = append(bits, uint8(v.Bit(i)))
+	}
+	return bits
+}
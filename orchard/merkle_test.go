@@ -0,0 +1,135 @@
+package orchard_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/orchard"
+)
+
+func TestEmptyRoot_Vectors(t *testing.T) {
+	// Orchard empty subtree roots from the Zcash commitment tree test vectors; the altitude 32
+	// value is the anchor of the empty Orchard tree (finalorchardroot before NU5).
+	for _, tc := range []struct {
+		altitude uint8
+		want     string
+	}{
+		{0, "0200000000000000000000000000000000000000000000000000000000000000"},
+		{1, "d1ab2507c809c2713c000f525e9fbdcb06c958384e51b9cc7f792dde6c97f411"},
+		{2, "c7413f4614cd64043abbab7cc1095c9bb104231cea89e2c3e0df83769556d030"},
+		{3, "2111fc397753e5fd50ec74816df27d6ada7ed2a9ac3816aab2573c8fac794204"},
+		{32, "ae2935f1dfd8a24aed7c70df7de3a668eb7a49b1319880dde2bbd9031ae5d82f"},
+	} {
+		root := orchard.EmptyRoot(tc.altitude)
+		if got := hex.EncodeToString(root[:]); got != tc.want {
+			t.Fatalf("EmptyRoot(%d)=%s, want %s", tc.altitude, got, tc.want)
+		}
+	}
+}
+
+func TestMerkleHash(t *testing.T) {
+	e := orchard.EmptyRoot(4)
+	got, err := orchard.MerkleHash(4, e, e)
+	if err != nil {
+		t.Fatalf("MerkleHash: %v", err)
+	}
+	if got != orchard.EmptyRoot(5) {
+		t.Fatalf("MerkleHash(4, E4, E4) != E5")
+	}
+
+	var big [32]byte
+	for i := range big {
+		big[i] = 0xff
+	}
+	if _, err := orchard.MerkleHash(0, big, e); !errors.Is(err, orchard.ErrNonCanonical) {
+		t.Fatalf("err=%v, want ErrNonCanonical", err)
+	}
+	if _, err := orchard.MerkleHash(orchard.MerkleDepth, e, e); err == nil {
+		t.Fatalf("expected altitude error")
+	}
+}
+
+// tree computes the root of a tree holding leaves at positions 0..len(leaves)-1 and the auth
+// path of position pos.
+func tree(t *testing.T, leaves [][32]byte, pos uint32) ([32]byte, [][32]byte) {
+	t.Helper()
+	level := leaves
+	var path [][32]byte
+	idx := int(pos)
+	for h := range uint8(orchard.MerkleDepth) {
+		empty := orchard.EmptyRoot(h)
+		if sib := idx ^ 1; sib < len(level) {
+			path = append(path, level[sib])
+		} else {
+			path = append(path, empty)
+		}
+		var next [][32]byte
+		for i := 0; i < len(level); i += 2 {
+			right := empty
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			n, err := orchard.MerkleHash(h, level[i], right)
+			if err != nil {
+				t.Fatalf("MerkleHash: %v", err)
+			}
+			next = append(next, n)
+		}
+		level, idx = next, idx/2
+	}
+	return level[0], path
+}
+
+func hexPath(path [][32]byte) []string {
+	out := make([]string, len(path))
+	for i, n := range path {
+		out[i] = hex.EncodeToString(n[:])
+	}
+	return out
+}
+
+func TestVerifyWitness(t *testing.T) {
+	leaves := make([][32]byte, 5)
+	for i := range leaves {
+		leaves[i][0], leaves[i][1] = byte(i+1), 0x42
+	}
+	root, path := tree(t, leaves, 3)
+	rootHex := hex.EncodeToString(root[:])
+	cmx := hex.EncodeToString(leaves[3][:])
+
+	if err := orchard.VerifyWitness(rootHex, cmx, 3, hexPath(path)); err != nil {
+		t.Fatalf("VerifyWitness: %v", err)
+	}
+	got, err := orchard.Root(leaves[3], 3, path)
+	if err != nil || got != root {
+		t.Fatalf("Root=%x err=%v", got, err)
+	}
+
+	// The same path does not authenticate another leaf or another position.
+	if err := orchard.VerifyWitness(rootHex, hex.EncodeToString(leaves[2][:]), 3, hexPath(path)); !errors.Is(err, orchard.ErrRootMismatch) {
+		t.Fatalf("wrong cmx: err=%v", err)
+	}
+	if err := orchard.VerifyWitness(rootHex, cmx, 2, hexPath(path)); !errors.Is(err, orchard.ErrRootMismatch) {
+		t.Fatalf("wrong position: err=%v", err)
+	}
+
+	tampered := hexPath(path)
+	tampered[20] = hex.EncodeToString(leaves[0][:])
+	if err := orchard.VerifyWitness(rootHex, cmx, 3, tampered); !errors.Is(err, orchard.ErrRootMismatch) {
+		t.Fatalf("tampered path: err=%v", err)
+	}
+
+	if err := orchard.VerifyWitness(rootHex, cmx, 3, hexPath(path[:31])); err == nil {
+		t.Fatalf("expected short path error")
+	}
+	if err := orchard.VerifyWitness(rootHex, "zz", 3, hexPath(path)); err == nil {
+		t.Fatalf("expected hex error")
+	}
+	nonCanonical := hexPath(path)
+	nonCanonical[0] = strings.Repeat("ff", 32)
+	if err := orchard.VerifyWitness(rootHex, cmx, 3, nonCanonical); !errors.Is(err, orchard.ErrNonCanonical) {
+		t.Fatalf("non-canonical node: err=%v", err)
+	}
+}
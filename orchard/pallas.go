@@ -0,0 +1,152 @@
+package orchard
+
+import (
+	"math/big"
+
+	"github.com/Abdullah1738/juno-sdk-go/internal/blake2b"
+)
+
+// Pallas base field and curve (y^2 = x^3 + 5), and the 3-isogenous curve iso-Pallas
+// (y^2 = x^3 + isoA*x + isoB) used by the simplified SWU map of hash_to_curve.
+var (
+	fieldP = hexInt("40000000000000000000000000000000224698fc094cf91b992d30ed00000001")
+
+	isoA  = hexInt("18354a2eb0ea8c9c49be2d7258370742b74134581a27a59f92bb4b0b657a014b")
+	isoB  = big.NewInt(1265)
+	sswuZ = new(big.Int).Sub(fieldP, big.NewInt(13))
+
+	// isoKernelX is the x-coordinate of the generator of the kernel of the 3-isogeny from
+	// iso-Pallas to Pallas. isoMap applies Vélu's formulas for this kernel and then the
+	// isomorphism (x, y) -> (x/9, y/27) onto y^2 = x^3 + 5.
+	isoKernelX = hexInt("115468c111fb318052cfc0198fdb5ac34301a71d1ff0c7cd6a57031b4ba19471")
+	isoV, isoU = veluConstants(isoKernelX)
+	isoScaleX  = feInv(big.NewInt(9))
+	isoScaleY  = feInv(big.NewInt(27))
+)
+
+// point is an affine point; the zero value is the identity.
+type point struct {
+	x, y *big.Int
+}
+
+func (p point) isIdentity() bool { return p.x == nil }
+
+func hexInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("orchard: bad constant " + s)
+	}
+	return v
+}
+
+func veluConstants(x0 *big.Int) (v, u *big.Int) {
+	// v = 2 * (3*x0^2 + A), u = 4 * (x0^3 + A*x0 + B)
+	v = feMul(big.NewInt(2), feAdd(feMul(big.NewInt(3), feMul(x0, x0)), isoA))
+	u = feMul(big.NewInt(4), feAdd(feAdd(feMul(x0, feMul(x0, x0)), feMul(isoA, x0)), isoB))
+	return v, u
+}
+
+func feAdd(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Add(a, b), fieldP) }
+func feSub(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Sub(a, b), fieldP) }
+func feMul(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Mul(a, b), fieldP) }
+func feNeg(a *big.Int) *big.Int    { return new(big.Int).Mod(new(big.Int).Neg(a), fieldP) }
+func feInv(a *big.Int) *big.Int    { return new(big.Int).ModInverse(a, fieldP) }
+
+func feIsSquare(a *big.Int) bool { return a.Sign() == 0 || big.Jacobi(a, fieldP) == 1 }
+
+// add returns p + q on the short Weierstrass curve with coefficient a.
+func add(p, q point, a *big.Int) point {
+	switch {
+	case p.isIdentity():
+		return q
+	case q.isIdentity():
+		return p
+	}
+	var lambda *big.Int
+	if p.x.Cmp(q.x) == 0 {
+		if feAdd(p.y, q.y).Sign() == 0 {
+			return point{}
+		}
+		num := feAdd(feMul(big.NewInt(3), feMul(p.x, p.x)), a)
+		lambda = feMul(num, feInv(feAdd(p.y, p.y)))
+	} else {
+		lambda = feMul(feSub(q.y, p.y), feInv(feSub(q.x, p.x)))
+	}
+	x := feSub(feSub(feMul(lambda, lambda), p.x), q.x)
+	y := feSub(feMul(lambda, feSub(p.x, x)), p.y)
+	return point{x: x, y: y}
+}
+
+// hashToCurve implements GroupHash^P (hash_to_curve with the pasta_curves
+// "_XMD:BLAKE2b_SSWU_RO_" suite) for domain and msg.
+func hashToCurve(domain string, msg []byte) point {
+	u := hashToField(domain, msg)
+	r := add(mapToCurveSSWU(u[0]), mapToCurveSSWU(u[1]), isoA)
+	return isoMap(r)
+}
+
+// hashToField is expand_message_xmd with BLAKE2b-512 producing two base field elements.
+func hashToField(domain string, msg []byte) [2]*big.Int {
+	dst := []byte(domain + "-pallas_XMD:BLAKE2b_SSWU_RO_")
+	dst = append(dst, byte(len(dst)))
+
+	b0 := blake2b.Sum(blake2b.Size, nil, make([]byte, blake2b.BlockSize), msg, []byte{0, 2 * blake2b.Size, 0}, dst)
+	b1 := blake2b.Sum(blake2b.Size, nil, b0, []byte{1}, dst)
+	mix := make([]byte, len(b0))
+	for i := range mix {
+		mix[i] = b0[i] ^ b1[i]
+	}
+	b2 := blake2b.Sum(blake2b.Size, nil, mix, []byte{2}, dst)
+
+	return [2]*big.Int{
+		new(big.Int).Mod(new(big.Int).SetBytes(b1), fieldP),
+		new(big.Int).Mod(new(big.Int).SetBytes(b2), fieldP),
+	}
+}
+
+// mapToCurveSSWU is the simplified SWU map onto iso-Pallas (RFC 9380, section 6.6.2).
+func mapToCurveSSWU(u *big.Int) point {
+	zu2 := feMul(sswuZ, feMul(u, u))
+	tv1 := feAdd(feMul(zu2, zu2), zu2)
+
+	var x1 *big.Int
+	if tv1.Sign() == 0 {
+		x1 = feMul(isoB, feInv(feMul(sswuZ, isoA)))
+	} else {
+		x1 = feMul(feMul(feNeg(isoB), feInv(isoA)), feAdd(big.NewInt(1), feInv(tv1)))
+	}
+	x := x1
+	gx := isoRHS(x)
+	if !feIsSquare(gx) {
+		x = feMul(zu2, x1)
+		gx = isoRHS(x)
+	}
+	y := new(big.Int).ModSqrt(gx, fieldP)
+	if u.Bit(0) != y.Bit(0) {
+		y = feNeg(y)
+	}
+	return point{x: x, y: y}
+}
+
+func isoRHS(x *big.Int) *big.Int {
+	return feAdd(feAdd(feMul(x, feMul(x, x)), feMul(isoA, x)), isoB)
+}
+
+// isoMap maps a point of iso-Pallas to Pallas.
+func isoMap(p point) point {
+	if p.isIdentity() {
+		return point{}
+	}
+	d := feSub(p.x, isoKernelX)
+	if d.Sign() == 0 {
+		return point{}
+	}
+	di := feInv(d)
+	di2 := feMul(di, di)
+	di3 := feMul(di2, di)
+
+	x := feAdd(feAdd(p.x, feMul(isoV, di)), feMul(isoU, di2))
+	yScale := feSub(feSub(big.NewInt(1), feMul(isoV, di2)), feMul(big.NewInt(2), feMul(isoU, di3)))
+	y := feMul(p.y, yScale)
+	return point{x: feMul(x, isoScaleX), y: feMul(y, isoScaleY)}
+}
@@ -0,0 +1,43 @@
+package orchard
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestHashToCurve_SpendAuthBase(t *testing.T) {
+	// G^Orchard = GroupHash^P("z.cash:Orchard", "G"), the RedPallas spend authorization base
+	// point, in compressed form.
+	const want = "63c975b884721a8d0ca1707be30c7f0c5f445f3e7c188d3b06d6f128b32355b7"
+
+	p := hashToCurve("z.cash:Orchard", []byte("G"))
+	if !onPallas(p) {
+		t.Fatalf("point not on curve")
+	}
+	enc := encodeNode(p.x)
+	enc[31] |= byte(p.y.Bit(0)) << 7
+	if got := hex.EncodeToString(enc[:]); got != want {
+		t.Fatalf("G=%s, want %s", got, want)
+	}
+}
+
+func TestSinsemillaTable_OnCurve(t *testing.T) {
+	sinsemillaInit()
+	for _, j := range []int{0, 1, 511, 1023} {
+		if !onPallas(sinsemillaTables.s[j]) {
+			t.Fatalf("S(%d) not on curve", j)
+		}
+	}
+	if !onPallas(sinsemillaTables.merkleQ) {
+		t.Fatalf("Q not on curve")
+	}
+}
+
+func onPallas(p point) bool {
+	if p.isIdentity() {
+		return false
+	}
+	rhs := feAdd(feMul(p.x, feMul(p.x, p.x)), big.NewInt(5))
+	return feMul(p.y, p.y).Cmp(rhs) == 0
+}
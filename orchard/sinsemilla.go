@@ -0,0 +1,65 @@
+package orchard
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sync"
+)
+
+const (
+	// sinsemillaK is the number of message bits absorbed per round.
+	sinsemillaK = 10
+
+	merkleCRHDomain = "z.cash:Orchard-MerkleCRH"
+)
+
+var sinsemillaTables struct {
+	once sync.Once
+	s    [1 << sinsemillaK]point
+	// merkleQ is Q(merkleCRHDomain), the initial accumulator of MerkleCRH.
+	merkleQ point
+}
+
+func sinsemillaInit() {
+	sinsemillaTables.once.Do(func() {
+		var j [4]byte
+		for i := range sinsemillaTables.s {
+			binary.LittleEndian.PutUint32(j[:], uint32(i))
+			sinsemillaTables.s[i] = hashToCurve("z.cash:SinsemillaS", j[:])
+		}
+		sinsemillaTables.merkleQ = hashToCurve("z.cash:SinsemillaQ", []byte(merkleCRHDomain))
+	})
+}
+
+// addIncomplete is the incomplete addition of the Sinsemilla specification. It reports false
+// for the exceptional cases (an identity operand or equal x-coordinates).
+func addIncomplete(p, q point) (point, bool) {
+	if p.isIdentity() || q.isIdentity() || p.x.Cmp(q.x) == 0 {
+		return point{}, false
+	}
+	return add(p, q, new(big.Int)), true
+}
+
+// sinsemillaHash returns the x-coordinate of SinsemillaHashToPoint(q, bits), with bits
+// zero-padded to a multiple of sinsemillaK. ok is false if the hash is undefined (⊥).
+func sinsemillaHash(q point, bits []uint8) (x *big.Int, ok bool) {
+	sinsemillaInit()
+	acc := q
+	for i := 0; i < len(bits); i += sinsemillaK {
+		var m int
+		for k := 0; k < sinsemillaK && i+k < len(bits); k++ {
+			m |= int(bits[i+k]) << k
+		}
+		sum, ok := addIncomplete(acc, sinsemillaTables.s[m])
+		if !ok {
+			return nil, false
+		}
+		if acc, ok = addIncomplete(sum, acc); !ok {
+			return nil, false
+		}
+	}
+	if acc.isIdentity() {
+		return nil, false
+	}
+	return acc.x, true
+}
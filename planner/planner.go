@@ -0,0 +1,347 @@
+// Package planner assembles complete TxPlans from junocashd chain state and juno-scan notes
+// and witnesses. Witnesses are verified against their anchor before they are used.
+package planner
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Abdullah1738/juno-sdk-go/coinselect"
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/orchard"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+const (
+	// DefaultAnchorDepth is how far below the tip the anchor is chosen, so that short reorgs do
+	// not invalidate the plan.
+	DefaultAnchorDepth = 10
+	// DefaultExpiryDelta matches the zcashd default transaction expiry delta.
+	DefaultExpiryDelta = 40
+)
+
+// ChainClient is the subset of *junocashd.Client used by the planner. Note transactions are read
+// from their blocks, so the node does not need -txindex.
+type ChainClient interface {
+	GetBlockchainInfo(ctx context.Context) (*junocashd.BlockchainInfo, error)
+	GetBlockCount(ctx context.Context) (int64, error)
+	GetBlockHash(ctx context.Context, height int64) (string, error)
+	GetBlockRaw(ctx context.Context, blockHash string) ([]byte, error)
+}
+
+// ScanClient is the subset of *junoscan.Client used by the planner.
+type ScanClient interface {
+	ListWalletNotes(ctx context.Context, walletID string, onlyUnspent bool) ([]junoscan.WalletNote, error)
+	OrchardWitnessBatch(ctx context.Context, anchorHeight *int64, positions []uint32, opts junoscan.WitnessBatchOptions) (junoscan.OrchardWitnessResponse, error)
+}
+
+var (
+	_ ChainClient = (*junocashd.Client)(nil)
+	_ ScanClient  = (*junoscan.Client)(nil)
+)
+
+type Config struct {
+	Chain   ChainClient
+	Scanner ScanClient
+
+	WalletID      string
+	CoinType      uint32
+	Account       uint32
+	ChangeAddress string
+
+	// AnchorDepth is the number of blocks between the tip and the anchor. Zero means
+	// DefaultAnchorDepth.
+	AnchorDepth int64
+	// ExpiryDelta is the number of blocks after the tip at which the transaction expires. Zero
+	// means DefaultExpiryDelta.
+	ExpiryDelta int64
+
+	// Strategy, MinConfirmations and MaxActions are passed to coinselect.
+	Strategy         coinselect.Strategy
+	MinConfirmations int64
+	MaxActions       int
+
+	// Witness controls how witnesses for the selected notes are fetched.
+	Witness junoscan.WitnessBatchOptions
+}
+
+type Planner struct {
+	cfg Config
+
+	mu     sync.Mutex
+	params *types.ChainParams
+}
+
+func New(cfg Config) (*Planner, error) {
+	if cfg.Chain == nil {
+		return nil, errors.New("planner: chain client required")
+	}
+	if cfg.Scanner == nil {
+		return nil, errors.New("planner: scan client required")
+	}
+	cfg.WalletID = strings.TrimSpace(cfg.WalletID)
+	if cfg.WalletID == "" {
+		return nil, errors.New("planner: wallet_id required")
+	}
+	cfg.ChangeAddress = strings.TrimSpace(cfg.ChangeAddress)
+	if cfg.ChangeAddress == "" {
+		return nil, errors.New("planner: change address required")
+	}
+	if cfg.AnchorDepth <= 0 {
+		cfg.AnchorDepth = DefaultAnchorDepth
+	}
+	if cfg.ExpiryDelta <= 0 {
+		cfg.ExpiryDelta = DefaultExpiryDelta
+	}
+	if cfg.Strategy == "" {
+		cfg.Strategy = coinselect.LargestFirst
+	}
+	return &Planner{cfg: cfg}, nil
+}
+
+// Withdrawal plans a transaction paying outputs from the wallet, with change to the configured
+// change address.
+func (p *Planner) Withdrawal(ctx context.Context, outputs []types.TxOutput) (types.TxPlan, error) {
+	return p.pay(ctx, types.TxPlanKindWithdrawal, outputs)
+}
+
+// Rebalance plans a transfer of amount to another wallet of the same operator (e.g. hot to
+// cold), with change to the configured change address.
+func (p *Planner) Rebalance(ctx context.Context, toAddress string, amount types.Zatoshi) (types.TxPlan, error) {
+	return p.pay(ctx, types.TxPlanKindRebalance, []types.TxOutput{{
+		ToAddress: toAddress,
+		AmountZat: strconv.FormatInt(int64(amount), 10),
+	}})
+}
+
+// Sweep plans a transaction moving every eligible note (up to MaxActions) to toAddress, minus
+// the fee. The plan has no change.
+func (p *Planner) Sweep(ctx context.Context, toAddress string) (types.TxPlan, error) {
+	st, err := p.chainState(ctx)
+	if err != nil {
+		return types.TxPlan{}, err
+	}
+	notes, err := p.notes(ctx, st)
+	if err != nil {
+		return types.TxPlan{}, err
+	}
+	sel, err := coinselect.Select(notes, coinselect.Params{
+		Strategy:         coinselect.All,
+		Outputs:          1,
+		TipHeight:        st.tip,
+		MinConfirmations: p.cfg.MinConfirmations,
+		MaxActions:       p.cfg.MaxActions,
+	})
+	if err != nil {
+		return types.TxPlan{}, err
+	}
+	outputs := []types.TxOutput{{
+		ToAddress: toAddress,
+		AmountZat: strconv.FormatInt(int64(sel.Target), 10),
+	}}
+	return p.build(ctx, types.TxPlanKindSweep, st, outputs, sel)
+}
+
+func (p *Planner) pay(ctx context.Context, kind types.TxPlanKind, outputs []types.TxOutput) (types.TxPlan, error) {
+	if len(outputs) == 0 {
+		return types.TxPlan{}, invalid("at least one output required")
+	}
+	target, err := (types.TxPlan{Outputs: outputs}).OutputTotal()
+	if err != nil {
+		return types.TxPlan{}, invalid("%v", err)
+	}
+	st, err := p.chainState(ctx)
+	if err != nil {
+		return types.TxPlan{}, err
+	}
+	notes, err := p.notes(ctx, st)
+	if err != nil {
+		return types.TxPlan{}, err
+	}
+	sel, err := coinselect.Select(notes, coinselect.Params{
+		Strategy:         p.cfg.Strategy,
+		Target:           target,
+		Outputs:          len(outputs),
+		TipHeight:        st.tip,
+		MinConfirmations: p.cfg.MinConfirmations,
+		MaxActions:       p.cfg.MaxActions,
+	})
+	if err != nil {
+		return types.TxPlan{}, err
+	}
+	return p.build(ctx, kind, st, outputs, sel)
+}
+
+type chainState struct {
+	params       types.ChainParams
+	tip          int64
+	anchorHeight int64
+}
+
+func (p *Planner) chainState(ctx context.Context) (chainState, error) {
+	params, err := p.chainParams(ctx)
+	if err != nil {
+		return chainState{}, err
+	}
+	tip, err := p.cfg.Chain.GetBlockCount(ctx)
+	if err != nil {
+		return chainState{}, fmt.Errorf("planner: get block count: %w", err)
+	}
+	anchor := tip - p.cfg.AnchorDepth
+	if anchor < 1 {
+		return chainState{}, fmt.Errorf("planner: tip %d is below anchor depth %d", tip, p.cfg.AnchorDepth)
+	}
+	return chainState{params: params, tip: tip, anchorHeight: anchor}, nil
+}
+
+func (p *Planner) chainParams(ctx context.Context) (types.ChainParams, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.params != nil {
+		return *p.params, nil
+	}
+	info, err := p.cfg.Chain.GetBlockchainInfo(ctx)
+	if err != nil {
+		return types.ChainParams{}, fmt.Errorf("planner: get blockchain info: %w", err)
+	}
+	params, ok := types.ChainParamsFor(info.Chain)
+	if !ok {
+		return types.ChainParams{}, fmt.Errorf("planner: unsupported chain %q", info.Chain)
+	}
+	p.params = &params
+	return params, nil
+}
+
+// notes returns the wallet's unspent notes that are already in the tree at the anchor.
+func (p *Planner) notes(ctx context.Context, st chainState) ([]junoscan.WalletNote, error) {
+	all, err := p.cfg.Scanner.ListWalletNotes(ctx, p.cfg.WalletID, true)
+	if err != nil {
+		return nil, fmt.Errorf("planner: list notes: %w", err)
+	}
+	var out []junoscan.WalletNote
+	for _, n := range all {
+		if n.Height > 0 && n.Height <= st.anchorHeight {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+func (p *Planner) build(ctx context.Context, kind types.TxPlanKind, st chainState, outputs []types.TxOutput, sel coinselect.Selection) (types.TxPlan, error) {
+	spends := sel.SpendNotes()
+	if err := p.fillActions(ctx, sel.Notes, spends); err != nil {
+		return types.TxPlan{}, err
+	}
+
+	positions := make([]uint32, len(spends))
+	for i, s := range spends {
+		positions[i] = s.Position
+	}
+	anchorHeight := st.anchorHeight
+	witness, err := p.cfg.Scanner.OrchardWitnessBatch(ctx, &anchorHeight, positions, p.cfg.Witness)
+	if err != nil {
+		return types.TxPlan{}, fmt.Errorf("planner: orchard witness: %w", err)
+	}
+	if witness.AnchorHeight != anchorHeight {
+		return types.TxPlan{}, fmt.Errorf("planner: witness anchor height %d, requested %d", witness.AnchorHeight, anchorHeight)
+	}
+	paths := make(map[uint32][]string, len(witness.Paths))
+	for _, wp := range witness.Paths {
+		paths[wp.Position] = wp.AuthPath
+	}
+	for i := range spends {
+		path, ok := paths[spends[i].Position]
+		if !ok {
+			return types.TxPlan{}, fmt.Errorf("planner: witness missing position %d", spends[i].Position)
+		}
+		if err := orchard.VerifyWitness(witness.Root, spends[i].CMX, spends[i].Position, path); err != nil {
+			return types.TxPlan{}, fmt.Errorf("planner: witness for %s: %w", spends[i].NoteID, err)
+		}
+		spends[i].Path = path
+	}
+
+	plan := types.TxPlan{
+		Version:       types.V0,
+		Kind:          kind,
+		WalletID:      p.cfg.WalletID,
+		CoinType:      p.cfg.CoinType,
+		Account:       p.cfg.Account,
+		Chain:         st.params.Chain,
+		BranchID:      st.params.BranchID,
+		AnchorHeight:  uint32(anchorHeight),
+		Anchor:        witness.Root,
+		ExpiryHeight:  uint32(st.tip + p.cfg.ExpiryDelta),
+		Outputs:       outputs,
+		ChangeAddress: p.cfg.ChangeAddress,
+		FeeZat:        strconv.FormatInt(int64(sel.Fee), 10),
+		Notes:         spends,
+	}
+	if err := plan.Validate(); err != nil {
+		return types.TxPlan{}, err
+	}
+	return plan, nil
+}
+
+// fillActions copies the Orchard action fields of each note's transaction into spends. The
+// transactions are read from the blocks at the notes' heights.
+func (p *Planner) fillActions(ctx context.Context, notes []junoscan.WalletNote, spends []types.OrchardSpendNote) error {
+	blocks := map[int64]map[string]*junotx.Transaction{}
+	for i, n := range notes {
+		txs, ok := blocks[n.Height]
+		if !ok {
+			var err error
+			if txs, err = p.blockTransactions(ctx, n.Height); err != nil {
+				return err
+			}
+			blocks[n.Height] = txs
+		}
+		tx, ok := txs[n.TxID]
+		if !ok {
+			return fmt.Errorf("planner: transaction %s not in block at height %d", n.TxID, n.Height)
+		}
+		if tx.Orchard == nil || n.ActionIndex < 0 || int(n.ActionIndex) >= len(tx.Orchard.Actions) {
+			return fmt.Errorf("planner: transaction %s has no orchard action %d", n.TxID, n.ActionIndex)
+		}
+		a := tx.Orchard.Actions[n.ActionIndex]
+		spends[i].ActionNullifier = hex.EncodeToString(a.Nullifier[:])
+		spends[i].CMX = hex.EncodeToString(a.CMX[:])
+		spends[i].EphemeralKey = hex.EncodeToString(a.EphemeralKey[:])
+		spends[i].EncCiphertext = hex.EncodeToString(a.EncCiphertext[:])
+	}
+	return nil
+}
+
+// blockTransactions returns the transactions of the block at height by txid.
+func (p *Planner) blockTransactions(ctx context.Context, height int64) (map[string]*junotx.Transaction, error) {
+	hash, err := p.cfg.Chain.GetBlockHash(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("planner: get block hash %d: %w", height, err)
+	}
+	raw, err := p.cfg.Chain.GetBlockRaw(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("planner: get raw block %s: %w", hash, err)
+	}
+	block, err := junotx.ParseBlock(raw)
+	if err != nil {
+		return nil, fmt.Errorf("planner: parse block %s: %w", hash, err)
+	}
+	txs := make(map[string]*junotx.Transaction, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		txs[tx.TxID()] = tx
+	}
+	return txs, nil
+}
+
+func invalid(format string, args ...any) error {
+	return types.CodedError{
+		Code:    types.ErrCodeInvalidRequest,
+		Message: "planner: " + fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,303 @@
+package planner_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+	"github.com/Abdullah1738/juno-sdk-go/junocashd"
+	"github.com/Abdullah1738/juno-sdk-go/junoscan"
+	"github.com/Abdullah1738/juno-sdk-go/junotx"
+	"github.com/Abdullah1738/juno-sdk-go/orchard"
+	"github.com/Abdullah1738/juno-sdk-go/planner"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+type fakeChain struct {
+	chain string
+	tip   int64
+	// blocks holds the transactions of each block by height.
+	blocks map[int64][]*junotx.Transaction
+}
+
+func (f *fakeChain) GetBlockchainInfo(context.Context) (*junocashd.BlockchainInfo, error) {
+	return &junocashd.BlockchainInfo{Chain: f.chain, Blocks: f.tip}, nil
+}
+
+func (f *fakeChain) GetBlockCount(context.Context) (int64, error) { return f.tip, nil }
+
+func (f *fakeChain) GetBlockHash(_ context.Context, height int64) (string, error) {
+	if _, ok := f.blocks[height]; !ok {
+		return "", errors.New("block height out of range")
+	}
+	return fmt.Sprintf("%064x", height), nil
+}
+
+func (f *fakeChain) block(hash string) ([]*junotx.Transaction, error) {
+	height, err := strconv.ParseInt(hash, 16, 64)
+	if err != nil {
+		return nil, err
+	}
+	txs, ok := f.blocks[height]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return txs, nil
+}
+
+func (f *fakeChain) GetBlockRaw(_ context.Context, hash string) ([]byte, error) {
+	txs, err := f.block(hash)
+	if err != nil {
+		return nil, err
+	}
+	b := &junotx.Block{Header: junotx.BlockHeader{Version: 4}, Transactions: txs}
+	return b.Serialize(), nil
+}
+
+type fakeScan struct {
+	notes []junoscan.WalletNote
+	// leaves holds the note commitment tree by position; gaps are zero and treated as empty.
+	leaves      [][32]byte
+	tamper      bool
+	gotAnchor   *int64
+	gotPosition []uint32
+}
+
+func (f *fakeScan) ListWalletNotes(context.Context, string, bool) ([]junoscan.WalletNote, error) {
+	return f.notes, nil
+}
+
+func (f *fakeScan) OrchardWitnessBatch(_ context.Context, anchorHeight *int64, positions []uint32, _ junoscan.WitnessBatchOptions) (junoscan.OrchardWitnessResponse, error) {
+	f.gotAnchor = anchorHeight
+	f.gotPosition = positions
+	resp := junoscan.OrchardWitnessResponse{Status: "ok", AnchorHeight: *anchorHeight}
+	for _, pos := range positions {
+		root, path := witness(f.leaves, pos)
+		resp.Root = root
+		if f.tamper {
+			path[7] = path[8]
+		}
+		resp.Paths = append(resp.Paths, junoscan.OrchardWitnessPath{Position: pos, AuthPath: path})
+	}
+	return resp, nil
+}
+
+// witness returns the root of the tree holding leaves and the auth path of pos.
+func witness(leaves [][32]byte, pos uint32) (string, []string) {
+	level := make([][32]byte, len(leaves))
+	for i, l := range leaves {
+		if l == ([32]byte{}) {
+			l = orchard.EmptyRoot(0)
+		}
+		level[i] = l
+	}
+	var path []string
+	idx := int(pos)
+	for h := range uint8(orchard.MerkleDepth) {
+		empty := orchard.EmptyRoot(h)
+		sib := empty
+		if idx^1 < len(level) {
+			sib = level[idx^1]
+		}
+		path = append(path, hex.EncodeToString(sib[:]))
+		var next [][32]byte
+		for i := 0; i < len(level); i += 2 {
+			right := empty
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			n, err := orchard.MerkleHash(h, level[i], right)
+			if err != nil {
+				panic(err)
+			}
+			next = append(next, n)
+		}
+		level, idx = next, idx/2
+	}
+	return hex.EncodeToString(level[0][:]), path
+}
+
+func regtestAddress(t *testing.T, seed byte) string {
+	t.Helper()
+	data := make([]byte, address.OrchardReceiverSize)
+	for i := range data {
+		data[i] = seed + byte(i)
+	}
+	s, err := (&address.UnifiedAddress{
+		Network: address.Regtest,
+		Items:   []address.Item{{Typecode: address.TypecodeOrchard, Data: data}},
+	}).Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return s
+}
+
+// orchardTx returns a transaction with n Orchard actions whose fields are derived from seed.
+func orchardTx(seed byte, n int) *junotx.Transaction {
+	tx := &junotx.Transaction{
+		Version:           junotx.TxVersion5,
+		VersionGroupID:    junotx.TxVersionGroupID5,
+		ConsensusBranchID: types.ConsensusBranchID,
+		Orchard: &junotx.OrchardBundle{
+			Flags: junotx.OrchardFlagEnableSpends | junotx.OrchardFlagEnableOutputs,
+			Proof: []byte{1, 2, 3},
+		},
+	}
+	for i := 0; i < n; i++ {
+		var a junotx.OrchardAction
+		a.Nullifier[0], a.Nullifier[1] = seed, byte(i)
+		a.CMX = orchardCMX(seed, i)
+		a.EphemeralKey[0], a.EphemeralKey[1] = seed, byte(i)+0x20
+		a.EncCiphertext[0] = seed
+		tx.Orchard.Actions = append(tx.Orchard.Actions, a)
+	}
+	return tx
+}
+
+func orchardCMX(seed byte, i int) [32]byte {
+	return [32]byte{seed, byte(i) + 0x10}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func newPlanner(t *testing.T) (*planner.Planner, *fakeChain, *fakeScan, map[string]string) {
+	t.Helper()
+	txA, txB := orchardTx(0xa0, 2), orchardTx(0xb0, 1)
+	txidA, txidB := txA.TxID(), txB.TxID()
+	chain := &fakeChain{chain: "regtest", tip: 200, blocks: map[int64][]*junotx.Transaction{
+		100: {txA},
+		150: {orchardTx(0xc0, 1), txB},
+		195: {txB},
+	}}
+	scan := &fakeScan{notes: []junoscan.WalletNote{
+		{TxID: txidA, ActionIndex: 1, Height: 100, Position: ptr(int64(5)), ValueZat: 70_000},
+		{TxID: txidB, ActionIndex: 0, Height: 150, Position: ptr(int64(9)), ValueZat: 50_000},
+		{TxID: txidA, ActionIndex: 0, Height: 100, Position: ptr(int64(4)), ValueZat: 20_000},
+		// Above the anchor (tip 200 - depth 10): cannot be witnessed yet.
+		{TxID: txidB, ActionIndex: 0, Height: 195, Position: ptr(int64(11)), ValueZat: 900_000},
+	}}
+	scan.leaves = make([][32]byte, 12)
+	scan.leaves[4], scan.leaves[5] = orchardCMX(0xa0, 0), orchardCMX(0xa0, 1)
+	scan.leaves[9], scan.leaves[11] = orchardCMX(0xb0, 0), orchardCMX(0xb0, 0)
+	p, err := planner.New(planner.Config{
+		Chain:         chain,
+		Scanner:       scan,
+		WalletID:      "hot",
+		CoinType:      8135,
+		ChangeAddress: regtestAddress(t, 1),
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return p, chain, scan, map[string]string{"a": txidA, "b": txidB}
+}
+
+func TestPlanner_Withdrawal(t *testing.T) {
+	p, _, scan, txids := newPlanner(t)
+	dest := regtestAddress(t, 50)
+
+	plan, err := p.Withdrawal(context.Background(), []types.TxOutput{{ToAddress: dest, AmountZat: "100000"}})
+	if err != nil {
+		t.Fatalf("Withdrawal: %v", err)
+	}
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if plan.Kind != types.TxPlanKindWithdrawal || plan.Chain != "regtest" || plan.BranchID != types.ConsensusBranchID {
+		t.Fatalf("header=%+v", plan)
+	}
+	if plan.AnchorHeight != 190 || *scan.gotAnchor != 190 || plan.ExpiryHeight != 240 {
+		t.Fatalf("anchor_height=%d expiry=%d", plan.AnchorHeight, plan.ExpiryHeight)
+	}
+	root, path5 := witness(scan.leaves, 5)
+	if plan.Anchor != root {
+		t.Fatalf("anchor=%s", plan.Anchor)
+	}
+	if plan.FeeZat != "10000" {
+		t.Fatalf("fee=%s", plan.FeeZat)
+	}
+	if len(plan.Notes) != 2 {
+		t.Fatalf("notes=%d", len(plan.Notes))
+	}
+
+	first := plan.Notes[0]
+	if first.NoteID != txids["a"]+":1" || first.Position != 5 || first.ValueZat != "70000" {
+		t.Fatalf("note=%+v", first)
+	}
+	if !strings.HasPrefix(first.ActionNullifier, "a001") || !strings.HasPrefix(first.CMX, "a011") || !strings.HasPrefix(first.EphemeralKey, "a021") {
+		t.Fatalf("action fields not copied: %+v", first)
+	}
+	if len(first.EncCiphertext) != 2*junotx.EncCiphertextSize {
+		t.Fatalf("enc_ciphertext len=%d", len(first.EncCiphertext))
+	}
+	if !slices.Equal(first.Path, path5) {
+		t.Fatalf("path=%v", first.Path)
+	}
+	if plan.Notes[1].Position != 9 || len(plan.Notes[1].Path) != 32 {
+		t.Fatalf("second note=%+v", plan.Notes[1])
+	}
+}
+
+func TestPlanner_SweepAndRebalance(t *testing.T) {
+	p, _, _, _ := newPlanner(t)
+	cold := regtestAddress(t, 90)
+
+	sweep, err := p.Sweep(context.Background(), cold)
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if sweep.Kind != types.TxPlanKindSweep || len(sweep.Notes) != 3 {
+		t.Fatalf("sweep kind=%s notes=%d", sweep.Kind, len(sweep.Notes))
+	}
+	if sweep.Outputs[0].AmountZat != "125000" || sweep.FeeZat != "15000" {
+		t.Fatalf("sweep output=%s fee=%s", sweep.Outputs[0].AmountZat, sweep.FeeZat)
+	}
+
+	rebalance, err := p.Rebalance(context.Background(), cold, 30_000)
+	if err != nil {
+		t.Fatalf("Rebalance: %v", err)
+	}
+	if rebalance.Kind != types.TxPlanKindRebalance || len(rebalance.Notes) != 1 || rebalance.Notes[0].Position != 5 {
+		t.Fatalf("rebalance=%+v", rebalance)
+	}
+}
+
+func TestPlanner_Errors(t *testing.T) {
+	p, chain, scan, _ := newPlanner(t)
+
+	_, err := p.Withdrawal(context.Background(), []types.TxOutput{{ToAddress: regtestAddress(t, 3), AmountZat: "900000"}})
+	var ce types.CodedError
+	if !errors.As(err, &ce) || ce.Code != types.ErrCodeInsufficientBalance {
+		t.Fatalf("err=%v, want insufficient balance", err)
+	}
+
+	_, err = p.Withdrawal(context.Background(), []types.TxOutput{{ToAddress: "j1bad", AmountZat: "1000"}})
+	if !errors.As(err, &ce) || ce.Code != types.ErrCodeInvalidRequest {
+		t.Fatalf("err=%v, want invalid request", err)
+	}
+
+	// The note of txB at height 150 is read from a block that no longer holds it.
+	chain.blocks[150] = chain.blocks[150][:1]
+	_, err = p.Withdrawal(context.Background(), []types.TxOutput{{ToAddress: regtestAddress(t, 3), AmountZat: "100000"}})
+	if err == nil || !strings.Contains(err.Error(), "not in block at height 150") {
+		t.Fatalf("err=%v, want missing transaction", err)
+	}
+
+	scan.tamper = true
+	_, err = p.Rebalance(context.Background(), regtestAddress(t, 3), 30_000)
+	if !errors.Is(err, orchard.ErrRootMismatch) {
+		t.Fatalf("err=%v, want ErrRootMismatch", err)
+	}
+
+	if _, err := planner.New(planner.Config{}); err == nil {
+		t.Fatalf("expected config error")
+	}
+}
@@ -9,3 +9,32 @@ type ReorgEvent struct {
 	From ChainCursor `json:"from"`
 	To   ChainCursor `json:"to"`
 }
+
+// ConsensusBranchID is the branch id of the network upgrade currently active on all Juno networks.
+const ConsensusBranchID uint32 = 0x4dec4df0
+
+// ChainParams holds the per-network constants a TxPlan must agree with.
+type ChainParams struct {
+	Chain    string `json:"chain"`
+	BranchID uint32 `json:"branch_id"`
+}
+
+var (
+	MainnetParams = ChainParams{Chain: "main", BranchID: ConsensusBranchID}
+	TestnetParams = ChainParams{Chain: "test", BranchID: ConsensusBranchID}
+	RegtestParams = ChainParams{Chain: "regtest", BranchID: ConsensusBranchID}
+)
+
+// ChainParamsFor returns the parameters for a chain name as reported by getblockchaininfo.
+func ChainParamsFor(chain string) (ChainParams, bool) {
+	switch chain {
+	case MainnetParams.Chain:
+		return MainnetParams, true
+	case TestnetParams.Chain:
+		return TestnetParams, true
+	case RegtestParams.Chain:
+		return RegtestParams, true
+	default:
+		return ChainParams{}, false
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 type TxState string
 
 const (
@@ -27,3 +29,11 @@ type DepositEvent struct {
 	MemoHex          string   `json:"memo_hex,omitempty"`
 	Status           TxStatus `json:"status"`
 }
+
+// Amount returns AmountZatoshis as a range-checked Zatoshi.
+func (e DepositEvent) Amount() (Zatoshi, error) {
+	if e.AmountZatoshis > uint64(MaxMoney) {
+		return 0, fmt.Errorf("%w: %d", ErrAmountOutOfRange, e.AmountZatoshis)
+	}
+	return Zatoshi(e.AmountZatoshis), nil
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ZIP-317 conventional fee parameters.
+const (
+	MarginalFee  Zatoshi = 5_000
+	GraceActions         = 2
+	// MinOrchardActions is the number of actions an Orchard bundle is padded to.
+	MinOrchardActions = 2
+)
+
+// ConventionalFee returns the ZIP-317 conventional fee for a transaction with the given number
+// of logical actions.
+func ConventionalFee(logicalActions int) Zatoshi {
+	return MarginalFee * Zatoshi(max(GraceActions, logicalActions))
+}
+
+// OrchardActions returns the number of actions of an Orchard bundle with the given spends and
+// outputs, including padding.
+func OrchardActions(spends, outputs int) int {
+	if spends == 0 && outputs == 0 {
+		return 0
+	}
+	return max(spends, outputs, MinOrchardActions)
+}
+
+// EstimateFee returns the ZIP-317 conventional fee for p: one Orchard action per spent note and
+// per output, plus a change output when the notes exceed the outputs by more than the fee of a
+// transaction with change. A smaller excess cannot fund a change output and is paid as fee.
+//
+// Notes without ValueZat are assumed to produce change, which can only overestimate the fee.
+func EstimateFee(p TxPlan) (Zatoshi, error) {
+	fee, _, err := estimateFee(p)
+	return fee, err
+}
+
+// WithConventionalFee returns a copy of p with FeeZat set to EstimateFee(p). When the excess of
+// the notes over the outputs is too small to fund a change output, the whole excess is used as
+// fee so that the plan has no change.
+func (p TxPlan) WithConventionalFee() (TxPlan, error) {
+	fee, change, err := estimateFee(p)
+	if err != nil {
+		return TxPlan{}, err
+	}
+	if !change {
+		if remainder, ok := p.remainder(); ok && remainder > fee {
+			fee = remainder
+		}
+	}
+	p.FeeZat = strconv.FormatInt(int64(fee), 10)
+	return p, nil
+}
+
+func estimateFee(p TxPlan) (fee Zatoshi, change bool, err error) {
+	if _, err := p.OutputTotal(); err != nil {
+		return 0, false, err
+	}
+	noChange := ConventionalFee(OrchardActions(len(p.Notes), len(p.Outputs)))
+	withChange := ConventionalFee(OrchardActions(len(p.Notes), len(p.Outputs)+1))
+
+	for i, n := range p.Notes {
+		if n.ValueZat == "" {
+			return withChange, true, nil
+		}
+		if _, err := n.Value(); err != nil {
+			return 0, false, fmt.Errorf("types: note %d: %w", i, err)
+		}
+	}
+	if remainder, ok := p.remainder(); !ok || remainder <= withChange {
+		return noChange, false, nil
+	}
+	return withChange, true, nil
+}
+
+// remainder returns the notes total minus the outputs total, or false if it is negative or any
+// amount is invalid.
+func (p TxPlan) remainder() (Zatoshi, bool) {
+	outputs, err := p.OutputTotal()
+	if err != nil {
+		return 0, false
+	}
+	var inputs Zatoshi
+	for _, n := range p.Notes {
+		v, err := n.Value()
+		if err != nil {
+			return 0, false
+		}
+		if inputs, err = inputs.Add(v); err != nil {
+			return 0, false
+		}
+	}
+	r, err := inputs.Sub(outputs)
+	if err != nil {
+		return 0, false
+	}
+	return r, true
+}
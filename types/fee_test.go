@@ -0,0 +1,97 @@
+package types_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func feePlan(notes []string, outputs ...string) types.TxPlan {
+	p := types.TxPlan{Version: types.V0, Kind: types.TxPlanKindWithdrawal}
+	for _, v := range notes {
+		p.Notes = append(p.Notes, types.OrchardSpendNote{ValueZat: v})
+	}
+	for _, a := range outputs {
+		p.Outputs = append(p.Outputs, types.TxOutput{AmountZat: a})
+	}
+	return p
+}
+
+func TestConventionalFee(t *testing.T) {
+	tests := []struct {
+		actions int
+		want    types.Zatoshi
+	}{
+		{0, 10_000},
+		{1, 10_000},
+		{2, 10_000},
+		{3, 15_000},
+		{10, 50_000},
+	}
+	for _, tc := range tests {
+		if got := types.ConventionalFee(tc.actions); got != tc.want {
+			t.Fatalf("ConventionalFee(%d)=%d, want %d", tc.actions, got, tc.want)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	many := make([]string, 7)
+	for i := range many {
+		many[i] = "10000"
+	}
+
+	tests := []struct {
+		name string
+		plan types.TxPlan
+		want types.Zatoshi
+		// wantFeeZat is the FeeZat set by WithConventionalFee, if different from want.
+		wantFeeZat string
+	}{
+		{"one note one output with change", feePlan([]string{"100000"}, "50000"), 10_000, ""},
+		{"exact amount, no change", feePlan([]string{"60000"}, "50000"), 10_000, ""},
+		{"two outputs plus change", feePlan([]string{"100000"}, "10000", "10000"), 15_000, ""},
+		{"two outputs exact", feePlan([]string{"30000"}, "10000", "10000"), 10_000, ""},
+		{"three outputs exact", feePlan([]string{"45000"}, "10000", "10000", "10000"), 15_000, ""},
+		{"sweep of many notes", feePlan(many, "35000"), 35_000, ""},
+		{"unknown note values assume change", feePlan([]string{"", ""}, "1", "2"), 15_000, ""},
+		{"excess too small for change", feePlan([]string{"32000"}, "10000", "10000"), 10_000, "12000"},
+		{"excess funds change", feePlan([]string{"35001"}, "10000", "10000"), 15_000, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := types.EstimateFee(tc.plan)
+			if err != nil {
+				t.Fatalf("EstimateFee: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("EstimateFee=%d, want %d", got, tc.want)
+			}
+
+			withFee, err := tc.plan.WithConventionalFee()
+			if err != nil {
+				t.Fatalf("WithConventionalFee: %v", err)
+			}
+			wantFeeZat := tc.wantFeeZat
+			if wantFeeZat == "" {
+				wantFeeZat = strconv.FormatInt(int64(tc.want), 10)
+			}
+			if withFee.FeeZat != wantFeeZat {
+				t.Fatalf("FeeZat=%q", withFee.FeeZat)
+			}
+			if tc.plan.FeeZat != "" {
+				t.Fatalf("WithConventionalFee modified the receiver")
+			}
+		})
+	}
+}
+
+func TestEstimateFee_InvalidAmounts(t *testing.T) {
+	if _, err := types.EstimateFee(feePlan([]string{"abc"}, "1")); err == nil {
+		t.Fatalf("expected note value error")
+	}
+	if _, err := types.EstimateFee(feePlan([]string{"1"}, "-1")); err == nil {
+		t.Fatalf("expected output amount error")
+	}
+}
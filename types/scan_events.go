@@ -1,6 +1,9 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // WalletEventKind is the "kind" discriminator used by juno-scan wallet events and broker envelopes.
 type WalletEventKind string
@@ -127,3 +130,95 @@ type OutgoingOutputUnconfirmedPayload struct {
 	RequiredConfirmations   int64 `json:"required_confirmations,omitempty"`
 	PreviousConfirmedHeight int64 `json:"previous_confirmed_height"`
 }
+
+// UnknownKindError is returned when a payload is decoded for a kind without a registered type.
+type UnknownKindError struct {
+	Kind WalletEventKind
+}
+
+func (e *UnknownKindError) Error() string {
+	return fmt.Sprintf("types: unknown wallet event kind %q", e.Kind)
+}
+
+// PayloadTypeError is returned by As when the payload type registered for a kind is not T.
+type PayloadTypeError struct {
+	Kind WalletEventKind
+	Want string
+	Got  string
+}
+
+func (e *PayloadTypeError) Error() string {
+	return fmt.Sprintf("types: %s payload is %s, not %s", e.Kind, e.Got, e.Want)
+}
+
+type payloadDecoder func(json.RawMessage) (any, error)
+
+func decodeInto[T any](raw json.RawMessage) (any, error) {
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+var payloadRegistry = map[WalletEventKind]payloadDecoder{
+	WalletEventKindDepositEvent:       decodeInto[DepositEventPayload],
+	WalletEventKindDepositConfirmed:   decodeInto[DepositConfirmedPayload],
+	WalletEventKindDepositOrphaned:    decodeInto[DepositOrphanedPayload],
+	WalletEventKindDepositUnconfirmed: decodeInto[DepositUnconfirmedPayload],
+
+	WalletEventKindSpendEvent:       decodeInto[SpendEventPayload],
+	WalletEventKindSpendConfirmed:   decodeInto[SpendConfirmedPayload],
+	WalletEventKindSpendOrphaned:    decodeInto[SpendOrphanedPayload],
+	WalletEventKindSpendUnconfirmed: decodeInto[SpendUnconfirmedPayload],
+
+	WalletEventKindOutgoingOutputEvent:       decodeInto[OutgoingOutputEventPayload],
+	WalletEventKindOutgoingOutputConfirmed:   decodeInto[OutgoingOutputConfirmedPayload],
+	WalletEventKindOutgoingOutputOrphaned:    decodeInto[OutgoingOutputOrphanedPayload],
+	WalletEventKindOutgoingOutputUnconfirmed: decodeInto[OutgoingOutputUnconfirmedPayload],
+	// juno-scan defines no payload of its own for expired outputs: they carry the plain
+	// outgoing output payload, whose ExpiryHeight and Status describe the expiry.
+	WalletEventKindOutgoingOutputExpired: decodeInto[OutgoingOutputEventPayload],
+}
+
+// Known reports whether k has a registered payload type.
+func (k WalletEventKind) Known() bool {
+	_, ok := payloadRegistry[k]
+	return ok
+}
+
+// DecodePayload decodes raw into the payload type registered for kind, e.g. a
+// DepositConfirmedPayload for WalletEventKindDepositConfirmed. The result is a value, not a
+// pointer. Unregistered kinds return *UnknownKindError.
+func DecodePayload(kind WalletEventKind, raw json.RawMessage) (any, error) {
+	dec, ok := payloadRegistry[kind]
+	if !ok {
+		return nil, &UnknownKindError{Kind: kind}
+	}
+	v, err := dec(raw)
+	if err != nil {
+		return nil, fmt.Errorf("types: decode %s payload: %w", kind, err)
+	}
+	return v, nil
+}
+
+// As decodes raw as kind and returns it as T. It fails with *PayloadTypeError if kind is
+// registered with a different payload type, so a switch over kinds cannot silently pick the
+// wrong struct.
+func As[T any](kind WalletEventKind, raw json.RawMessage) (T, error) {
+	var zero T
+	v, err := DecodePayload(kind, raw)
+	if err != nil {
+		return zero, err
+	}
+	t, ok := v.(T)
+	if !ok {
+		return zero, &PayloadTypeError{Kind: kind, Want: fmt.Sprintf("%T", zero), Got: fmt.Sprintf("%T", v)}
+	}
+	return t, nil
+}
+
+// DecodePayload decodes e.Payload according to e.Kind. See DecodePayload.
+func (e BrokerEnvelope) DecodePayload() (any, error) {
+	return DecodePayload(e.Kind, e.Payload)
+}
@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -71,3 +72,173 @@ func TestDepositConfirmedPayload_JSONRoundTrip(t *testing.T) {
 		t.Fatalf("round-trip mismatch:\n  in=%#v\n out=%#v", in, out)
 	}
 }
+
+func checkPayloadRoundTrip[T any](t *testing.T, kind types.WalletEventKind, in T) {
+	t.Helper()
+	raw, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("%s: marshal: %v", kind, err)
+	}
+
+	got, err := types.As[T](kind, raw)
+	if err != nil {
+		t.Fatalf("%s: As: %v", kind, err)
+	}
+	if !reflect.DeepEqual(in, got) {
+		t.Fatalf("%s: round-trip mismatch:\n  in=%#v\n out=%#v", kind, in, got)
+	}
+
+	env := types.BrokerEnvelope{Version: types.V1, Kind: kind, WalletID: "hot", Payload: raw}
+	v, err := env.DecodePayload()
+	if err != nil {
+		t.Fatalf("%s: DecodePayload: %v", kind, err)
+	}
+	if !reflect.DeepEqual(any(in), v) {
+		t.Fatalf("%s: DecodePayload returned %T, want %T", kind, v, in)
+	}
+}
+
+func TestDecodePayload_AllKinds(t *testing.T) {
+	status := types.TxStatus{State: types.TxStateConfirmed, Height: 100, Confirmations: 3}
+	deposit := types.DepositEventPayload{
+		DepositEvent: types.DepositEvent{
+			Version:          types.V1,
+			WalletID:         "hot",
+			DiversifierIndex: 7,
+			TxID:             "deadbeef",
+			Height:           100,
+			ActionIndex:      3,
+			AmountZatoshis:   5000,
+			MemoHex:          "00",
+			Status:           status,
+		},
+		RecipientAddress: "j1recipient",
+		NoteNullifier:    "nf",
+	}
+	spend := types.SpendEventPayload{
+		Version:         types.V1,
+		WalletID:        "hot",
+		TxID:            "cafe",
+		Height:          101,
+		NoteTxID:        "deadbeef",
+		NoteActionIndex: 3,
+		NoteHeight:      100,
+		AmountZatoshis:  5000,
+		NoteNullifier:   "nf",
+		Status:          status,
+	}
+	h, exp := int64(102), int64(140)
+	outgoing := types.OutgoingOutputEventPayload{
+		Version:          types.V1,
+		WalletID:         "hot",
+		TxID:             "beef",
+		Height:           &h,
+		ExpiryHeight:     &exp,
+		ActionIndex:      1,
+		AmountZatoshis:   4000,
+		RecipientAddress: "j1dest",
+		OvkScope:         "external",
+		Status:           status,
+	}
+
+	kinds := map[types.WalletEventKind]bool{}
+	check := func(kind types.WalletEventKind, f func()) {
+		kinds[kind] = true
+		f()
+	}
+
+	check(types.WalletEventKindDepositEvent, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindDepositEvent, deposit)
+	})
+	check(types.WalletEventKindDepositConfirmed, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindDepositConfirmed, types.DepositConfirmedPayload{
+			DepositEventPayload: deposit, ConfirmedHeight: 102, RequiredConfirmations: 3,
+		})
+	})
+	check(types.WalletEventKindDepositOrphaned, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindDepositOrphaned, types.DepositOrphanedPayload{
+			DepositEventPayload: deposit, OrphanedAtHeight: 100,
+		})
+	})
+	check(types.WalletEventKindDepositUnconfirmed, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindDepositUnconfirmed, types.DepositUnconfirmedPayload{
+			DepositEventPayload: deposit, RollbackHeight: 101, RequiredConfirmations: 3, PreviousConfirmedHeight: 102,
+		})
+	})
+	check(types.WalletEventKindSpendEvent, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindSpendEvent, spend)
+	})
+	check(types.WalletEventKindSpendConfirmed, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindSpendConfirmed, types.SpendConfirmedPayload{
+			SpendEventPayload: spend, ConfirmedHeight: 103, RequiredConfirmations: 3,
+		})
+	})
+	check(types.WalletEventKindSpendOrphaned, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindSpendOrphaned, types.SpendOrphanedPayload{
+			SpendEventPayload: spend, OrphanedAtHeight: 101,
+		})
+	})
+	check(types.WalletEventKindSpendUnconfirmed, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindSpendUnconfirmed, types.SpendUnconfirmedPayload{
+			SpendEventPayload: spend, RollbackHeight: 102, PreviousConfirmedHeight: 103,
+		})
+	})
+	check(types.WalletEventKindOutgoingOutputEvent, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindOutgoingOutputEvent, outgoing)
+	})
+	check(types.WalletEventKindOutgoingOutputConfirmed, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindOutgoingOutputConfirmed, types.OutgoingOutputConfirmedPayload{
+			OutgoingOutputEventPayload: outgoing, ConfirmedHeight: 104, RequiredConfirmations: 3,
+		})
+	})
+	check(types.WalletEventKindOutgoingOutputOrphaned, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindOutgoingOutputOrphaned, types.OutgoingOutputOrphanedPayload{
+			OutgoingOutputEventPayload: outgoing, OrphanedAtHeight: 102,
+		})
+	})
+	check(types.WalletEventKindOutgoingOutputUnconfirmed, func() {
+		checkPayloadRoundTrip(t, types.WalletEventKindOutgoingOutputUnconfirmed, types.OutgoingOutputUnconfirmedPayload{
+			OutgoingOutputEventPayload: outgoing, RollbackHeight: 103, RequiredConfirmations: 3, PreviousConfirmedHeight: 104,
+		})
+	})
+	check(types.WalletEventKindOutgoingOutputExpired, func() {
+		out := outgoing
+		out.Height = nil
+		out.Status = types.TxStatus{State: types.TxStateExpired}
+		checkPayloadRoundTrip(t, types.WalletEventKindOutgoingOutputExpired, out)
+	})
+
+	for kind := range kinds {
+		if !kind.Known() {
+			t.Fatalf("%s not registered", kind)
+		}
+	}
+	if len(kinds) != 13 {
+		t.Fatalf("covered %d kinds, want 13", len(kinds))
+	}
+}
+
+func TestDecodePayload_UnknownKind(t *testing.T) {
+	_, err := types.DecodePayload("Mystery", json.RawMessage(`{}`))
+	var uk *types.UnknownKindError
+	if !errors.As(err, &uk) || uk.Kind != "Mystery" {
+		t.Fatalf("err=%v, want *UnknownKindError", err)
+	}
+	if types.WalletEventKind("Mystery").Known() {
+		t.Fatalf("Mystery reported as known")
+	}
+}
+
+func TestAs_TypeMismatch(t *testing.T) {
+	_, err := types.As[types.SpendEventPayload](types.WalletEventKindDepositEvent, json.RawMessage(`{"txid":"aa"}`))
+	var pt *types.PayloadTypeError
+	if !errors.As(err, &pt) {
+		t.Fatalf("err=%v, want *PayloadTypeError", err)
+	}
+}
+
+func TestDecodePayload_InvalidJSON(t *testing.T) {
+	if _, err := types.DecodePayload(types.WalletEventKindSpendEvent, json.RawMessage(`{"height":"x"}`)); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
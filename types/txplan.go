@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 type TxPlanKind string
 
 const (
@@ -16,6 +18,7 @@ type TxOutput struct {
 
 type OrchardSpendNote struct {
 	NoteID          string   `json:"note_id,omitempty"`
+	ValueZat        string   `json:"value_zat,omitempty"`
 	ActionNullifier string   `json:"action_nullifier"`
 	CMX             string   `json:"cmx"`
 	Position        uint32   `json:"position"`
@@ -41,3 +44,33 @@ type TxPlan struct {
 	Notes         []OrchardSpendNote `json:"notes"`
 	Metadata      any                `json:"metadata,omitempty"`
 }
+
+// Amount parses AmountZat.
+func (o TxOutput) Amount() (Zatoshi, error) {
+	return ParseZatoshi(o.AmountZat)
+}
+
+// Fee parses FeeZat.
+func (p TxPlan) Fee() (Zatoshi, error) {
+	return ParseZatoshi(p.FeeZat)
+}
+
+// Value parses ValueZat.
+func (n OrchardSpendNote) Value() (Zatoshi, error) {
+	return ParseZatoshi(n.ValueZat)
+}
+
+// OutputTotal returns the checked sum of all output amounts.
+func (p TxPlan) OutputTotal() (Zatoshi, error) {
+	var total Zatoshi
+	for i, o := range p.Outputs {
+		amt, err := o.Amount()
+		if err != nil {
+			return 0, fmt.Errorf("types: output %d: %w", i, err)
+		}
+		if total, err = total.Add(amt); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
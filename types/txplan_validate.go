@@ -0,0 +1,190 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+)
+
+const (
+	// OrchardMerkleDepth is the depth of the Orchard note commitment tree, i.e. the
+	// number of sibling hashes in an OrchardSpendNote.Path.
+	OrchardMerkleDepth = 32
+	// MaxMemoBytes is the size of an Orchard memo field.
+	MaxMemoBytes = 512
+)
+
+// Validate checks p against the parameters of its own Chain. See Check.
+func (p TxPlan) Validate() error {
+	params, ok := ChainParamsFor(p.Chain)
+	if !ok {
+		return invalidPlan("chain: unknown chain %q", p.Chain)
+	}
+	return p.Check(params)
+}
+
+// Check performs structural and economic checks on p before it is handed to a signer.
+// It returns a CodedError with ErrCodeInvalidRequest describing the first problem found.
+func (p TxPlan) Check(params ChainParams) error {
+	if p.Version != V0 {
+		return invalidPlan("version: want %q, got %q", V0, p.Version)
+	}
+	switch p.Kind {
+	case TxPlanKindWithdrawal, TxPlanKindSweep, TxPlanKindRebalance:
+	default:
+		return invalidPlan("kind: unknown kind %q", p.Kind)
+	}
+	if p.WalletID == "" {
+		return invalidPlan("wallet_id: required")
+	}
+	if p.Chain != params.Chain {
+		return invalidPlan("chain: plan is for %q, want %q", p.Chain, params.Chain)
+	}
+	if p.BranchID != params.BranchID {
+		return invalidPlan("branch_id: 0x%08x does not match chain %q (0x%08x)", p.BranchID, params.Chain, params.BranchID)
+	}
+	net, err := address.ParseNetwork(p.Chain)
+	if err != nil {
+		return invalidPlan("chain: %v", err)
+	}
+
+	if err := checkHex32("anchor", p.Anchor); err != nil {
+		return err
+	}
+	if p.ExpiryHeight <= p.AnchorHeight {
+		return invalidPlan("expiry_height: %d must be greater than anchor_height %d", p.ExpiryHeight, p.AnchorHeight)
+	}
+
+	if len(p.Outputs) == 0 {
+		return invalidPlan("outputs: at least one output required")
+	}
+	var outputs Zatoshi
+	for i, o := range p.Outputs {
+		field := fmt.Sprintf("outputs[%d]", i)
+		if err := address.ValidateAddressForNetwork(o.ToAddress, net); err != nil {
+			return invalidPlan("%s.to_address: %v", field, err)
+		}
+		amt, err := o.Amount()
+		if err != nil {
+			return invalidPlan("%s.amount_zat: %v", field, err)
+		}
+		if amt <= 0 {
+			return invalidPlan("%s.amount_zat: must be positive", field)
+		}
+		if outputs, err = outputs.Add(amt); err != nil {
+			return invalidPlan("outputs: %v", err)
+		}
+		if o.MemoHex != "" {
+			memo, err := hex.DecodeString(o.MemoHex)
+			if err != nil {
+				return invalidPlan("%s.memo_hex: invalid hex", field)
+			}
+			if len(memo) > MaxMemoBytes {
+				return invalidPlan("%s.memo_hex: %d bytes exceeds %d", field, len(memo), MaxMemoBytes)
+			}
+		}
+	}
+	if p.ChangeAddress != "" {
+		if err := address.ValidateAddressForNetwork(p.ChangeAddress, net); err != nil {
+			return invalidPlan("change_address: %v", err)
+		}
+	}
+
+	fee, err := p.Fee()
+	if err != nil {
+		return invalidPlan("fee_zat: %v", err)
+	}
+	if fee <= 0 {
+		return invalidPlan("fee_zat: must be positive")
+	}
+
+	if len(p.Notes) == 0 {
+		return invalidPlan("notes: at least one note required")
+	}
+	var inputs Zatoshi
+	positions := make(map[uint32]int, len(p.Notes))
+	for i, n := range p.Notes {
+		field := fmt.Sprintf("notes[%d]", i)
+		if prev, ok := positions[n.Position]; ok {
+			return invalidPlan("%s.position: %d duplicates notes[%d]", field, n.Position, prev)
+		}
+		positions[n.Position] = i
+
+		for _, f := range []struct {
+			name  string
+			value string
+		}{
+			{"action_nullifier", n.ActionNullifier},
+			{"cmx", n.CMX},
+			{"ephemeral_key", n.EphemeralKey},
+		} {
+			if err := checkHex32(field+"."+f.name, f.value); err != nil {
+				return err
+			}
+		}
+		if n.EncCiphertext == "" {
+			return invalidPlan("%s.enc_ciphertext: required", field)
+		}
+		if _, err := hex.DecodeString(n.EncCiphertext); err != nil {
+			return invalidPlan("%s.enc_ciphertext: invalid hex", field)
+		}
+		if len(n.Path) != OrchardMerkleDepth {
+			return invalidPlan("%s.path: want %d nodes, got %d", field, OrchardMerkleDepth, len(n.Path))
+		}
+		for j, node := range n.Path {
+			if err := checkHex32(fmt.Sprintf("%s.path[%d]", field, j), node); err != nil {
+				return err
+			}
+		}
+
+		v, err := n.Value()
+		if err != nil {
+			return invalidPlan("%s.value_zat: %v", field, err)
+		}
+		if v <= 0 {
+			return invalidPlan("%s.value_zat: must be positive", field)
+		}
+		if inputs, err = inputs.Add(v); err != nil {
+			return invalidPlan("notes: %v", err)
+		}
+	}
+
+	minFee, err := EstimateFee(p)
+	if err != nil {
+		return invalidPlan("fee_zat: %v", err)
+	}
+	if fee < minFee {
+		return invalidPlan("fee_zat: %d is below the ZIP-317 conventional fee %d", fee, minFee)
+	}
+
+	required, err := outputs.Add(fee)
+	if err != nil {
+		return invalidPlan("outputs: %v", err)
+	}
+	if inputs < required {
+		return invalidPlan("notes: total %d does not cover outputs %d plus fee %d", inputs, outputs, fee)
+	}
+	if inputs > required && p.ChangeAddress == "" {
+		return invalidPlan("change_address: required for change of %d", inputs-required)
+	}
+	return nil
+}
+
+func checkHex32(field, s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return invalidPlan("%s: invalid hex", field)
+	}
+	if len(b) != 32 {
+		return invalidPlan("%s: want 32 bytes, got %d", field, len(b))
+	}
+	return nil
+}
+
+func invalidPlan(format string, args ...any) error {
+	return CodedError{
+		Code:    ErrCodeInvalidRequest,
+		Message: "txplan: " + fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,145 @@
+package types_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/address"
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func testAddress(t *testing.T, net address.Network, seed byte) string {
+	t.Helper()
+	data := make([]byte, address.OrchardReceiverSize)
+	for i := range data {
+		data[i] = seed + byte(i)
+	}
+	ua := &address.UnifiedAddress{
+		Network: net,
+		Items:   []address.Item{{Typecode: address.TypecodeOrchard, Data: data}},
+	}
+	s, err := ua.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return s
+}
+
+func hex32(b byte) string {
+	return strings.Repeat(string("0123456789abcdef"[b%16])+"0", 32)
+}
+
+func validPlan(t *testing.T) types.TxPlan {
+	t.Helper()
+	path := make([]string, types.OrchardMerkleDepth)
+	for i := range path {
+		path[i] = hex32(byte(i))
+	}
+	note := func(pos uint32, value string) types.OrchardSpendNote {
+		return types.OrchardSpendNote{
+			ValueZat:        value,
+			ActionNullifier: hex32(1),
+			CMX:             hex32(2),
+			Position:        pos,
+			Path:            append([]string(nil), path...),
+			EphemeralKey:    hex32(3),
+			EncCiphertext:   "00ff",
+		}
+	}
+	return types.TxPlan{
+		Version:      types.V0,
+		Kind:         types.TxPlanKindWithdrawal,
+		WalletID:     "hot",
+		CoinType:     8135,
+		Chain:        "regtest",
+		BranchID:     types.ConsensusBranchID,
+		AnchorHeight: 100,
+		Anchor:       hex32(9),
+		ExpiryHeight: 140,
+		Outputs: []types.TxOutput{
+			{ToAddress: testAddress(t, address.Regtest, 1), AmountZat: "90000", MemoHex: "cafe"},
+		},
+		ChangeAddress: testAddress(t, address.Regtest, 50),
+		FeeZat:        "10000",
+		Notes:         []types.OrchardSpendNote{note(4, "60000"), note(7, "40000")},
+	}
+}
+
+func TestTxPlan_Validate(t *testing.T) {
+	if err := validPlan(t).Validate(); err != nil {
+		t.Fatalf("Validate(valid plan): %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(p *types.TxPlan)
+		want   string
+	}{
+		{"version", func(p *types.TxPlan) { p.Version = "v1" }, "version"},
+		{"kind", func(p *types.TxPlan) { p.Kind = "mint" }, "kind"},
+		{"unknown chain", func(p *types.TxPlan) { p.Chain = "signet" }, "chain"},
+		{"branch id", func(p *types.TxPlan) { p.BranchID = 0xc2d6d0b4 }, "branch_id"},
+		{"anchor hex", func(p *types.TxPlan) { p.Anchor = "zz" }, "anchor: invalid hex"},
+		{"anchor length", func(p *types.TxPlan) { p.Anchor = "00" }, "anchor: want 32 bytes"},
+		{"expiry", func(p *types.TxPlan) { p.ExpiryHeight = p.AnchorHeight }, "expiry_height"},
+		{"no outputs", func(p *types.TxPlan) { p.Outputs = nil }, "outputs: at least one"},
+		{"output address", func(p *types.TxPlan) { p.Outputs[0].ToAddress = "jregtest1qqqq" }, "outputs[0].to_address"},
+		{"output network", func(p *types.TxPlan) { p.Outputs[0].ToAddress = testAddress(t, address.Mainnet, 1) }, "outputs[0].to_address"},
+		{"output amount", func(p *types.TxPlan) { p.Outputs[0].AmountZat = "1.5" }, "outputs[0].amount_zat"},
+		{"output zero", func(p *types.TxPlan) { p.Outputs[0].AmountZat = "0" }, "outputs[0].amount_zat: must be positive"},
+		{"memo hex", func(p *types.TxPlan) { p.Outputs[0].MemoHex = "abc" }, "outputs[0].memo_hex"},
+		{"memo size", func(p *types.TxPlan) { p.Outputs[0].MemoHex = strings.Repeat("00", 513) }, "outputs[0].memo_hex: 513 bytes"},
+		{"change address", func(p *types.TxPlan) { p.ChangeAddress = "jregtest1qqqq" }, "change_address"},
+		{"change without address", func(p *types.TxPlan) { p.ChangeAddress, p.Notes[0].ValueZat = "", "70000" }, "change_address: required for change of 10000"},
+		{"fee", func(p *types.TxPlan) { p.FeeZat = "" }, "fee_zat"},
+		{"fee zero", func(p *types.TxPlan) { p.FeeZat = "0" }, "fee_zat: must be positive"},
+		{"no notes", func(p *types.TxPlan) { p.Notes = nil }, "notes: at least one"},
+		{"duplicate position", func(p *types.TxPlan) { p.Notes[1].Position = 4 }, "notes[1].position: 4 duplicates notes[0]"},
+		{"nullifier", func(p *types.TxPlan) { p.Notes[0].ActionNullifier = "" }, "notes[0].action_nullifier"},
+		{"cmx", func(p *types.TxPlan) { p.Notes[1].CMX = "xx" }, "notes[1].cmx"},
+		{"ephemeral key", func(p *types.TxPlan) { p.Notes[0].EphemeralKey = hex32(1)[:62] }, "notes[0].ephemeral_key"},
+		{"ciphertext", func(p *types.TxPlan) { p.Notes[0].EncCiphertext = "" }, "notes[0].enc_ciphertext"},
+		{"short path", func(p *types.TxPlan) { p.Notes[1].Path = p.Notes[1].Path[:31] }, "notes[1].path: want 32 nodes, got 31"},
+		{"path node", func(p *types.TxPlan) { p.Notes[0].Path[5] = "00" }, "notes[0].path[5]"},
+		{"note value", func(p *types.TxPlan) { p.Notes[0].ValueZat = "" }, "notes[0].value_zat"},
+		{"insufficient", func(p *types.TxPlan) { p.Notes[1].ValueZat = "39999" }, "notes: total 99999 does not cover outputs 90000 plus fee 10000"},
+		{"underpaid fee", func(p *types.TxPlan) { p.FeeZat = "9999" }, "below the ZIP-317 conventional fee 10000"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := validPlan(t)
+			tc.mutate(&p)
+			err := p.Validate()
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			var ce types.CodedError
+			if !errors.As(err, &ce) || ce.Code != types.ErrCodeInvalidRequest {
+				t.Fatalf("err=%v, want CodedError with %s", err, types.ErrCodeInvalidRequest)
+			}
+			if !strings.Contains(ce.Message, tc.want) {
+				t.Fatalf("message=%q, want substring %q", ce.Message, tc.want)
+			}
+		})
+	}
+}
+
+func TestTxPlan_ValidateNoChange(t *testing.T) {
+	p := validPlan(t)
+	p.Kind = types.TxPlanKindSweep
+	p.ChangeAddress = ""
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate(sweep without change): %v", err)
+	}
+}
+
+func TestTxPlan_CheckParams(t *testing.T) {
+	p := validPlan(t)
+	if err := p.Check(types.RegtestParams); err != nil {
+		t.Fatalf("Check(regtest): %v", err)
+	}
+	if err := p.Check(types.MainnetParams); err == nil {
+		t.Fatalf("expected chain mismatch")
+	}
+}
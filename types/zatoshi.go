@@ -0,0 +1,184 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Zatoshi is an amount in the smallest unit of JUNO (1 JUNO = 10^8 zatoshi).
+//
+// Arithmetic helpers are checked: they fail instead of overflowing or leaving the
+// [0, MaxMoney] range. JSON encodes as a number and decodes from either a number
+// or a decimal string ("12345"), matching the string-typed *Zat fields of TxPlan.
+type Zatoshi int64
+
+const (
+	ZatoshiPerJUNO Zatoshi = 100_000_000
+	// MaxMoney is the consensus MAX_MONEY bound inherited from zcashd.
+	MaxMoney Zatoshi = 21_000_000 * ZatoshiPerJUNO
+
+	junoDecimals = 8
+)
+
+var ErrAmountOutOfRange = errors.New("types: amount out of range")
+
+func (z Zatoshi) Valid() bool {
+	return z >= 0 && z <= MaxMoney
+}
+
+func (z Zatoshi) check() (Zatoshi, error) {
+	if !z.Valid() {
+		return 0, fmt.Errorf("%w: %d", ErrAmountOutOfRange, int64(z))
+	}
+	return z, nil
+}
+
+// Add returns z+o, or an error if either operand or the result is outside [0, MaxMoney].
+func (z Zatoshi) Add(o Zatoshi) (Zatoshi, error) {
+	if _, err := z.check(); err != nil {
+		return 0, err
+	}
+	if _, err := o.check(); err != nil {
+		return 0, err
+	}
+	// Both operands are bounded by MaxMoney, so the sum cannot overflow int64.
+	return (z + o).check()
+}
+
+// Sub returns z-o, or an error if either operand or the result is outside [0, MaxMoney].
+func (z Zatoshi) Sub(o Zatoshi) (Zatoshi, error) {
+	if _, err := z.check(); err != nil {
+		return 0, err
+	}
+	if _, err := o.check(); err != nil {
+		return 0, err
+	}
+	return (z - o).check()
+}
+
+// Mul returns z*n, or an error if the result is outside [0, MaxMoney].
+func (z Zatoshi) Mul(n int64) (Zatoshi, error) {
+	if _, err := z.check(); err != nil {
+		return 0, err
+	}
+	if n < 0 || (n > 0 && int64(z) > int64(MaxMoney)/n) {
+		return 0, fmt.Errorf("%w: %d * %d", ErrAmountOutOfRange, int64(z), n)
+	}
+	return (z * Zatoshi(n)).check()
+}
+
+// SumZatoshi adds all values with range checking.
+func SumZatoshi(values ...Zatoshi) (Zatoshi, error) {
+	var total Zatoshi
+	for _, v := range values {
+		var err error
+		if total, err = total.Add(v); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// ParseZatoshi parses a non-negative integer amount of zatoshi, e.g. TxOutput.AmountZat.
+func ParseZatoshi(s string) (Zatoshi, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "+") {
+		return 0, fmt.Errorf("types: invalid zatoshi amount %q", s)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("types: invalid zatoshi amount %q", s)
+	}
+	return Zatoshi(n).check()
+}
+
+// ParseJUNO parses a decimal JUNO amount with up to 8 fractional digits, e.g. "1.2345".
+func ParseJUNO(s string) (Zatoshi, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, hasDot := strings.Cut(s, ".")
+	if (whole == "" && frac == "") || (hasDot && frac == "") || !allDigits(whole) || !allDigits(frac) {
+		return 0, fmt.Errorf("types: invalid JUNO amount %q", s)
+	}
+	if len(frac) > junoDecimals {
+		return 0, fmt.Errorf("types: JUNO amount %q has more than %d decimals", s, junoDecimals)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if len(whole) > 10 {
+		return 0, fmt.Errorf("%w: %s JUNO", ErrAmountOutOfRange, s)
+	}
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("types: invalid JUNO amount %q", s)
+	}
+	var f int64
+	if frac != "" {
+		f, err = strconv.ParseInt(frac+strings.Repeat("0", junoDecimals-len(frac)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("types: invalid JUNO amount %q", s)
+		}
+	}
+	return Zatoshi(w*int64(ZatoshiPerJUNO) + f).check()
+}
+
+// FormatJUNO formats z as a decimal JUNO amount without trailing zeros, e.g. "1.2345".
+func (z Zatoshi) FormatJUNO() string {
+	// Take the magnitude in uint64 so that math.MinInt64 does not overflow.
+	sign := ""
+	v := uint64(z)
+	if z < 0 {
+		sign = "-"
+		v = -v
+	}
+	whole := v / uint64(ZatoshiPerJUNO)
+	frac := v % uint64(ZatoshiPerJUNO)
+	if frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	fs := strings.TrimRight(fmt.Sprintf("%08d", frac), "0")
+	return fmt.Sprintf("%s%d.%s", sign, whole, fs)
+}
+
+func (z Zatoshi) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(z), 10), nil
+}
+
+func (z *Zatoshi) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		v, err := ParseZatoshi(s)
+		if err != nil {
+			return err
+		}
+		*z = v
+		return nil
+	}
+	v, err := ParseZatoshi(string(b))
+	if err != nil {
+		return err
+	}
+	*z = v
+	return nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Abdullah1738/juno-sdk-go/types"
+)
+
+func TestParseJUNO(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    types.Zatoshi
+		wantErr bool
+	}{
+		{in: "1.2345", want: 123450000},
+		{in: "0", want: 0},
+		{in: "0.00000001", want: 1},
+		{in: ".5", want: 50000000},
+		{in: "21000000", want: types.MaxMoney},
+		{in: "21000000.00000001", wantErr: true},
+		{in: "0.000000001", wantErr: true},
+		{in: "-1", wantErr: true},
+		{in: "1.", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "99999999999", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := types.ParseJUNO(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("ParseJUNO(%q): expected error, got %d", tc.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("ParseJUNO(%q)=%d, %v want %d", tc.in, got, err, tc.want)
+		}
+	}
+}
+
+func TestZatoshi_FormatJUNO(t *testing.T) {
+	tests := map[types.Zatoshi]string{
+		0:              "0",
+		1:              "0.00000001",
+		123450000:      "1.2345",
+		100000000:      "1",
+		types.MaxMoney: "21000000",
+		-50000000:      "-0.5",
+		math.MinInt64:  "-92233720368.54775808",
+	}
+	for in, want := range tests {
+		if got := in.FormatJUNO(); got != want {
+			t.Fatalf("FormatJUNO(%d)=%q want %q", in, got, want)
+		}
+	}
+}
+
+func TestZatoshi_CheckedArithmetic(t *testing.T) {
+	if got, err := types.Zatoshi(5).Add(7); err != nil || got != 12 {
+		t.Fatalf("Add=%d, %v", got, err)
+	}
+	if _, err := types.MaxMoney.Add(1); !errors.Is(err, types.ErrAmountOutOfRange) {
+		t.Fatalf("Add past MaxMoney: err=%v", err)
+	}
+	if _, err := types.Zatoshi(-1).Add(1); !errors.Is(err, types.ErrAmountOutOfRange) {
+		t.Fatalf("Add negative operand: err=%v", err)
+	}
+	if _, err := types.Zatoshi(5).Sub(6); !errors.Is(err, types.ErrAmountOutOfRange) {
+		t.Fatalf("Sub below zero: err=%v", err)
+	}
+	if got, err := types.Zatoshi(5000).Mul(3); err != nil || got != 15000 {
+		t.Fatalf("Mul=%d, %v", got, err)
+	}
+	if _, err := types.MaxMoney.Mul(1 << 40); !errors.Is(err, types.ErrAmountOutOfRange) {
+		t.Fatalf("Mul overflow: err=%v", err)
+	}
+	if _, err := types.SumZatoshi(types.MaxMoney, types.MaxMoney); err == nil {
+		t.Fatalf("expected SumZatoshi overflow error")
+	}
+	if got, err := types.SumZatoshi(1, 2, 3); err != nil || got != 6 {
+		t.Fatalf("SumZatoshi=%d, %v", got, err)
+	}
+}
+
+func TestZatoshi_JSON(t *testing.T) {
+	var v struct {
+		A types.Zatoshi `json:"a"`
+		B types.Zatoshi `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":12345,"b":"678"}`), &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if v.A != 12345 || v.B != 678 {
+		t.Fatalf("got=%+v", v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(b) != `{"a":12345,"b":678}` {
+		t.Fatalf("json=%s", b)
+	}
+
+	for _, bad := range []string{`{"a":-1}`, `{"a":"1.5"}`, `{"a":1.5}`, `{"a":"abc"}`, `{"a":2100000000000001}`} {
+		if err := json.Unmarshal([]byte(bad), &v); err == nil {
+			t.Fatalf("%s: expected error", bad)
+		}
+	}
+}
+
+func TestTxPlan_Amounts(t *testing.T) {
+	plan := types.TxPlan{
+		FeeZat: "10000",
+		Outputs: []types.TxOutput{
+			{AmountZat: "5000"},
+			{AmountZat: "7000"},
+		},
+	}
+	fee, err := plan.Fee()
+	if err != nil || fee != 10000 {
+		t.Fatalf("Fee=%d, %v", fee, err)
+	}
+	total, err := plan.OutputTotal()
+	if err != nil || total != 12000 {
+		t.Fatalf("OutputTotal=%d, %v", total, err)
+	}
+
+	plan.Outputs[1].AmountZat = "7000.5"
+	if _, err := plan.OutputTotal(); err == nil {
+		t.Fatalf("expected parse error")
+	}
+	plan.Outputs[1].AmountZat = "9223372036854775807"
+	if _, err := plan.OutputTotal(); err == nil {
+		t.Fatalf("expected range error")
+	}
+}